@@ -0,0 +1,63 @@
+package lolp
+
+import "context"
+
+//go:generate moq -out lolpmock/api_mock.go -pkg lolpmock . API
+
+// ProjectService interface for operations on projects
+type ProjectService interface {
+	Projects() (*[]Project, error)
+	ProjectsContext(ctx context.Context) (*[]Project, error)
+	ProjectsPage(ctx context.Context, opts *ListOptions) ([]Project, *ListOptions, error)
+	Project(name string) (*Project, error)
+	ProjectContext(ctx context.Context, name string) (*Project, error)
+	CreateProject(p *ProjectNew) (*ProjectCreateResponse, error)
+	CreateProjectContext(ctx context.Context, p *ProjectNew) (*ProjectCreateResponse, error)
+	DeleteProject(name string) error
+	DeleteProjectContext(ctx context.Context, name string) error
+	EnableAutoscaling(name string) error
+	EnableAutoscalingContext(ctx context.Context, name string) error
+	DisableAutoscaling(name string) error
+	DisableAutoscalingContext(ctx context.Context, name string) error
+}
+
+// EnvService interface for operations on environment variables of project
+type EnvService interface {
+	GetEnvironmentVariables(name string) (string, error)
+	GetEnvironmentVariablesContext(ctx context.Context, name string) (string, error)
+	UpdateEnvironmentVariables(name string, params []UpdateEnvironmentVariablesParam) error
+	UpdateEnvironmentVariablesContext(ctx context.Context, name string, params []UpdateEnvironmentVariablesParam) error
+}
+
+// PublicKeyService interface for operations on public keys
+type PublicKeyService interface {
+	AddPublicKey(p *PublicKey) (*PublicKey, error)
+	AddPublicKeyContext(ctx context.Context, p *PublicKey) (*PublicKey, error)
+	DeletePublicKey(name string) error
+	DeletePublicKeyContext(ctx context.Context, name string) error
+}
+
+// AuthService interface for operations on token
+type AuthService interface {
+	Authenticate(u string, p string) (string, error)
+	AuthenticateContext(ctx context.Context, u string, p string) (string, error)
+	AuthenticateWithOTP(u, p, otp string) (string, error)
+	AuthenticateWithOTPContext(ctx context.Context, u, p, otp string) (string, error)
+	Logout() error
+	LogoutContext(ctx context.Context) error
+	RevokeToken(token string) error
+	RevokeTokenContext(ctx context.Context, token string) error
+	TokenInfo() (*TokenInfo, error)
+	TokenInfoContext(ctx context.Context) (*TokenInfo, error)
+}
+
+// API interface for all operations of Client, so that callers can replace
+// it with mock such as lolpmock.APIMock in tests
+type API interface {
+	ProjectService
+	EnvService
+	PublicKeyService
+	AuthService
+}
+
+var _ API = (*Client)(nil)
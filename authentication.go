@@ -1,12 +1,34 @@
 package lolp
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"strings"
+	"path"
 )
 
 // Authenticate for authorization
 func (c *Client) Authenticate(u string, p string) (string, error) {
+	return c.AuthenticateContext(context.Background(), u, p)
+}
+
+// AuthenticateContext for authorization with context
+func (c *Client) AuthenticateContext(ctx context.Context, u string, p string) (string, error) {
+	return c.AuthenticateWithOTPContext(ctx, u, p, "")
+}
+
+// AuthenticateWithOTP for authorization of account with two-factor
+// authentication. Authenticate without OTP returns error matching
+// ErrOTPRequired for such account.
+func (c *Client) AuthenticateWithOTP(u, p, otp string) (string, error) {
+	return c.AuthenticateWithOTPContext(context.Background(), u, p, otp)
+}
+
+// AuthenticateWithOTPContext for authorization with one-time password and
+// context
+func (c *Client) AuthenticateWithOTPContext(ctx context.Context, u, p, otp string) (string, error) {
 	if len(u) == 0 {
 		return "", fmt.Errorf("client: missing username")
 	}
@@ -15,9 +37,17 @@ func (c *Client) Authenticate(u string, p string) (string, error) {
 		return "", fmt.Errorf("client: missing password")
 	}
 
-	json := fmt.Sprintf(`{"username":"%s","password":"%s"}`, u, p)
-	res, err := c.HTTP("POST", "/v1/authenticate", &RequestOptions{
-		Body: strings.NewReader(json),
+	body, err := json.Marshal(struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		OTP      string `json:"otp,omitempty"`
+	}{u, p, otp})
+	if err != nil {
+		return "", err
+	}
+
+	res, err := c.HTTPContext(ctx, "POST", authenticatePath, &RequestOptions{
+		Body: bytes.NewReader(body),
 	})
 	if err != nil {
 		return "", err
@@ -31,3 +61,55 @@ func (c *Client) Authenticate(u string, p string) (string, error) {
 
 	return t, nil
 }
+
+// Logout revokes token of client, and clears it
+func (c *Client) Logout() error {
+	return c.LogoutContext(context.Background())
+}
+
+// LogoutContext revokes token of client with context, and clears it. Token
+// already rejected by API is regarded as revoked. Credential provider of
+// username and password is not used, not to log in just for logout.
+func (c *Client) LogoutContext(ctx context.Context) error {
+	token, err := c.existingToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.RevokeTokenContext(ctx, token); err != nil && !errors.Is(err, ErrUnauthorized) {
+		return err
+	}
+	c.Token = ""
+
+	return nil
+}
+
+// RevokeToken invalidates token on API
+func (c *Client) RevokeToken(token string) error {
+	return c.RevokeTokenContext(context.Background(), token)
+}
+
+// RevokeTokenContext invalidates token on API with context
+func (c *Client) RevokeTokenContext(ctx context.Context, token string) error {
+	if len(token) == 0 {
+		return fmt.Errorf("client: missing token")
+	}
+
+	c.logger().Info("request", "verb", "DELETE", "path", authenticatePath)
+
+	u := *c.URL
+	u.Path = path.Join(c.URL.Path, authenticatePath)
+	req, err := c.rawRequest(ctx, "DELETE", &u, &RequestOptions{
+		Headers: map[string]string{"Authorization": fmt.Sprintf("Bearer %s", token)},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := c.dryRun(req); err != nil {
+		return err
+	}
+
+	_, err = c.send(ctx, req, false)
+	return err
+}
@@ -2,10 +2,12 @@ package lolp
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -74,3 +76,129 @@ func TestAuthenticate(t *testing.T) {
 		}
 	}
 }
+
+func TestAuthenticateWithOTP(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l := struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			OTP      string `json:"otp"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&l); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if l.Username != "foo@example.com" || l.Password != `Secret"Gopher123?` {
+			w.WriteHeader(http.StatusUnauthorized)
+			io.WriteString(w, `{"errors":["Invalid username or password"]}`)
+			return
+		}
+		if l.OTP != "123456" {
+			w.Header().Set(OTPHeader, "required; app")
+			w.WriteHeader(http.StatusUnauthorized)
+			io.WriteString(w, `{"errors":["One-time password required"]}`)
+			return
+		}
+		io.WriteString(w, `"token-with-otp"`)
+	}))
+	defer s.Close()
+
+	c, err := NewClient(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		password    string
+		otp         string
+		expectedErr error
+	}{
+		{`Secret"Gopher123?`, "123456", nil},
+		{`Secret"Gopher123?`, "", ErrOTPRequired},
+		{`Secret"Gopher123?`, "000000", ErrOTPRequired},
+		{"wrong", "123456", ErrUnauthorized},
+	}
+
+	for _, cc := range cases {
+		token, err := c.AuthenticateWithOTP("foo@example.com", cc.password, cc.otp)
+		if cc.expectedErr == nil {
+			if err != nil || token != "token-with-otp" {
+				t.Errorf("expect to succeed in authentication, but got %q, %v", token, err)
+			}
+			continue
+		}
+		if !errors.Is(err, cc.expectedErr) {
+			t.Errorf("password %s and otp %q expects %s, but got %v", cc.password, cc.otp, cc.expectedErr, err)
+		}
+		if cc.expectedErr == ErrUnauthorized && errors.Is(err, ErrOTPRequired) {
+			t.Errorf("wrong password expects not to require otp")
+		}
+	}
+}
+
+func TestLogout(t *testing.T) {
+	revoked := map[string]bool{}
+	requests := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		switch {
+		case r.Method != "DELETE" || r.URL.Path != "/v1/authenticate":
+			w.WriteHeader(http.StatusNotFound)
+		case token == "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		case token == "" || revoked[token]:
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			revoked[token] = true
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer s.Close()
+
+	c, err := NewClient(s.URL, WithToken("valid"), WithRetry(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Logout(); err != nil {
+		t.Fatal(err)
+	}
+	if !revoked["valid"] || c.Token != "" {
+		t.Errorf("logout expects to revoke and clear token, but token is %q", c.Token)
+	}
+
+	c.Token = "valid"
+	if err := c.Logout(); err != nil {
+		t.Errorf("logout with rejected token expects to succeed, but failed: %s", err)
+	}
+
+	c.Token = "broken"
+	if err := c.Logout(); !errors.Is(err, ErrServerError) {
+		t.Errorf("logout expects server error, but got %v", err)
+	}
+	if c.Token != "broken" {
+		t.Errorf("token expects to be kept on failure")
+	}
+
+	if err := c.RevokeToken(""); err == nil {
+		t.Errorf("revoking empty token expects error")
+	}
+
+	c, err = NewClient(s.URL, WithCredentialProvider(PasswordCredentials("foo@example.com", "secret")), WithRetry(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests = 0
+	if err := c.Logout(); err == nil {
+		t.Errorf("logout without token expects error")
+	}
+	if _, err := c.TokenInfo(); err == nil {
+		t.Errorf("token info without token expects error")
+	}
+	if requests != 0 {
+		t.Errorf("logout expects not to authenticate by password, but sent %d requests", requests)
+	}
+}
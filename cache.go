@@ -0,0 +1,206 @@
+package lolp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// cacheHeader marks response served from cache
+const cacheHeader = "X-Lolp-Cache"
+
+// CachedResponse struct for conditional request
+type CachedResponse struct {
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"lastModified,omitempty"`
+	StatusCode   int         `json:"statusCode"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+
+	// Owner is hash of token which received the response
+	Owner string `json:"owner,omitempty"`
+}
+
+// Cache interface for storing responses of read endpoints
+type Cache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, res *CachedResponse)
+}
+
+// WithCache sends conditional GET requests with ETag and Last-Modified
+// stored in cache, and serves 304 Not Modified responses from it
+func WithCache(cache Cache) Option {
+	return WithMiddleware(cacheMiddleware(cache))
+}
+
+// cacheKey returns key by URL, so that response by new token replaces one by
+// old token instead of leaving it orphaned
+func cacheKey(req *http.Request) string {
+	return req.URL.String()
+}
+
+// cacheOwner returns hash of token, not to share cache between accounts
+func cacheOwner(req *http.Request) string {
+	h := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return hex.EncodeToString(h[:8])
+}
+
+// cacheMiddleware returns middleware for conditional request
+func cacheMiddleware(cache Cache) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method != "GET" {
+				return next.RoundTrip(req)
+			}
+
+			key, owner := cacheKey(req), cacheOwner(req)
+			cached, ok := cache.Get(key)
+			ok = ok && cached.Owner == owner
+			if ok {
+				req = req.Clone(req.Context())
+				if cached.ETag != "" {
+					req.Header.Set("If-None-Match", cached.ETag)
+				}
+				if cached.LastModified != "" {
+					req.Header.Set("If-Modified-Since", cached.LastModified)
+				}
+			}
+
+			res, err := next.RoundTrip(req)
+			if err != nil {
+				return res, err
+			}
+
+			if ok && res.StatusCode == http.StatusNotModified {
+				res.Body.Close()
+				header := cached.Header.Clone()
+				header.Set(cacheHeader, "hit")
+				return &http.Response{
+					Status:        fmt.Sprintf("%d %s", cached.StatusCode, http.StatusText(cached.StatusCode)),
+					StatusCode:    cached.StatusCode,
+					Proto:         res.Proto,
+					ProtoMajor:    res.ProtoMajor,
+					ProtoMinor:    res.ProtoMinor,
+					Header:        header,
+					Body:          &bytesReadCloser{bytes.NewBuffer(cached.Body)},
+					ContentLength: int64(len(cached.Body)),
+					Request:       req,
+				}, nil
+			}
+
+			etag, lastModified := res.Header.Get("ETag"), res.Header.Get("Last-Modified")
+			if res.StatusCode != http.StatusOK || etag == "" && lastModified == "" {
+				return res, nil
+			}
+
+			body, err := ioutil.ReadAll(res.Body)
+			res.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			res.Body = &bytesReadCloser{bytes.NewBuffer(body)}
+
+			cache.Set(key, &CachedResponse{
+				ETag:         etag,
+				LastModified: lastModified,
+				StatusCode:   res.StatusCode,
+				Header:       res.Header.Clone(),
+				Body:         body,
+				Owner:        owner,
+			})
+
+			return res, nil
+		})
+	}
+}
+
+// MemoryCache struct stores responses in memory
+type MemoryCache struct {
+	mu    sync.RWMutex
+	items map[string]*CachedResponse
+}
+
+// NewMemoryCache returns empty memory cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{items: make(map[string]*CachedResponse)}
+}
+
+// Get returns cached response by key
+func (m *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	res, ok := m.items[key]
+	return res, ok
+}
+
+// Set stores response by key
+func (m *MemoryCache) Set(key string, res *CachedResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items[key] = res
+}
+
+// DiskCache struct stores responses as files in directory, which is
+// readable only by owner since responses may include secrets
+type DiskCache struct {
+	Dir string
+}
+
+// NewDiskCache returns disk cache in dir
+func NewDiskCache(dir string) *DiskCache {
+	return &DiskCache{Dir: dir}
+}
+
+// path returns file path by key
+func (d *DiskCache) path(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return filepath.Join(d.Dir, hex.EncodeToString(h[:])+".json")
+}
+
+// Get returns cached response by key, and treats broken file as miss
+func (d *DiskCache) Get(key string) (*CachedResponse, bool) {
+	b, err := ioutil.ReadFile(d.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var res CachedResponse
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, false
+	}
+
+	return &res, true
+}
+
+// Set stores response by key, and ignores errors as cache is best-effort
+func (d *DiskCache) Set(key string, res *CachedResponse) {
+	b, err := json.Marshal(res)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(d.Dir, 0700); err != nil {
+		return
+	}
+
+	tmp, err := ioutil.TempFile(d.Dir, "tmp-")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+	os.Rename(tmp.Name(), d.path(key))
+}
@@ -0,0 +1,101 @@
+package lolp
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func etagHandler(t *testing.T, full, notModified *int) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			*notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		*full++
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, fixture("ok.response", r))
+	}
+}
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lolp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	caches := map[string]Cache{
+		"memory": NewMemoryCache(),
+		"disk":   NewDiskCache(dir),
+	}
+
+	for name, cache := range caches {
+		var full, notModified int
+		s := httptest.NewServer(http.HandlerFunc(etagHandler(t, &full, &notModified)))
+
+		c, err := NewClient(s.URL, WithCache(cache))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < 3; i++ {
+			p, err := c.Project("rails-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.SubDomain != "rails-1" {
+				t.Errorf("%s cache returns wrong project: %#v", name, p)
+			}
+		}
+
+		c.Token = "another"
+		for i := 0; i < 2; i++ {
+			if _, err := c.Project("rails-1"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		s.Close()
+
+		if full != 2 || notModified != 3 {
+			t.Errorf("%s cache expects 2 full and 3 not modified responses, but got %d and %d", name, full, notModified)
+		}
+		if n := cacheSize(t, cache, dir); n != 1 {
+			t.Errorf("%s cache expects response by another token to replace old one, but got %d entries", name, n)
+		}
+	}
+}
+
+// cacheSize returns number of entries in cache
+func cacheSize(t *testing.T, cache Cache, dir string) int {
+	if m, ok := cache.(*MemoryCache); ok {
+		return len(m.items)
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(files)
+}
+
+func TestDiskCacheBrokenFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lolp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	d := NewDiskCache(dir)
+	if err := ioutil.WriteFile(d.path("key"), []byte("broken"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := d.Get("key"); ok {
+		t.Errorf("broken cache file expects to be miss")
+	}
+}
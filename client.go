@@ -2,17 +2,19 @@ package lolp
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
 	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	cleanhttp "github.com/hashicorp/go-cleanhttp"
 )
@@ -43,30 +45,52 @@ type Client struct {
 	HTTPClient    *http.Client
 	DefaultHeader http.Header
 	Token         string
+	Retry         *RetryPolicy
+	Logger        Logger
+	Middlewares   []Middleware
+	Credentials   CredentialProvider
+	// DryRun receives mutating requests instead of API when not nil
+	DryRun io.Writer
+
+	authMu sync.Mutex
 }
 
-// New returns client struct pointer
+// New returns client struct pointer configured by environment variables,
+// and panics on error. Use NewFromEnv to handle the error.
 func New() *Client {
+	client, err := NewFromEnv()
+	if err != nil {
+		panic(err)
+	}
+
+	return client
+}
+
+// NewFromEnv returns client struct pointer configured by environment
+// variables. Options are applied after the environment variables.
+func NewFromEnv(opts ...Option) (*Client, error) {
 	endpoint := os.Getenv(EndpointEnvVar)
 	if endpoint == "" {
 		endpoint = defaultEndpoint
 	}
 
-	client, err := NewClient(endpoint)
+	envOpts, err := tlsEnvOptions(os.Getenv)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-
-	token := os.Getenv(TokenEnvVar)
-	if token != "" {
-		client.Token = token
+	if proxy := os.Getenv(ProxyEnvVar); proxy != "" {
+		envOpts = append(envOpts, WithProxy(proxy, os.Getenv(NoProxyEnvVar)))
+	}
+	if token := os.Getenv(TokenEnvVar); token != "" {
+		envOpts = append(envOpts, WithToken(token))
 	}
 
-	return client
+	return NewClient(endpoint, append(envOpts, opts...)...)
 }
 
-// NewClient returns clean client struct pointer
-func NewClient(u string) (*Client, error) {
+// NewClient returns clean client struct pointer, which does not depend on
+// environment variables. Options are applied in order.
+func NewClient(u string, opts ...Option) (*Client, error) {
 	if len(u) == 0 {
 		return nil, fmt.Errorf("client: missing url")
 	}
@@ -85,6 +109,12 @@ func NewClient(u string) (*Client, error) {
 		return nil, err
 	}
 
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
 	return c, nil
 }
 
@@ -93,33 +123,68 @@ func (c *Client) init() error {
 	c.DefaultHeader.Set("User-Agent", userAgent)
 	c.DefaultHeader.Set("Content-Type", "application/json")
 
-	tlsConfig := &tls.Config{}
-	if os.Getenv(TLSNoVerifyEnvVar) != "" {
-		tlsConfig.InsecureSkipVerify = true
-	}
 	t := cleanhttp.DefaultTransport()
-	t.TLSClientConfig = tlsConfig
+	t.TLSClientConfig = &tls.Config{}
 	c.HTTPClient = &http.Client{Transport: t}
 
 	return nil
 }
 
+// transport returns transport of client to configure
+func (c *Client) transport() (*http.Transport, error) {
+	t, ok := c.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("client: transport is not *http.Transport")
+	}
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{}
+	}
+
+	return t, nil
+}
+
 // RequestOptions struct
 type RequestOptions struct {
-	Params     map[string]string
+	Params map[string]string
+	// Query is added to Params, keeping repeated keys
+	Query      url.Values
 	Headers    map[string]string
 	Body       io.Reader
 	BodyLength int64
+	// Stream keeps successful response body unbuffered for large payloads,
+	// and the caller must close it
+	Stream bool
 }
 
 // HTTP returns http.Response with dispose
 func (c *Client) HTTP(verb, spath string, ro *RequestOptions) (*http.Response, error) {
-	req, err := c.Request(verb, spath, ro)
+	return c.HTTPContext(context.Background(), verb, spath, ro)
+}
+
+// HTTPContext returns http.Response with dispose, aborting when ctx is done
+func (c *Client) HTTPContext(ctx context.Context, verb, spath string, ro *RequestOptions) (*http.Response, error) {
+	if err := c.prepareToken(ctx, spath); err != nil {
+		return nil, err
+	}
+
+	req, err := c.RequestContext(ctx, verb, spath, ro)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := dispose(c.HTTPClient.Do(req))
+	if err := c.dryRun(req); err != nil {
+		return nil, err
+	}
+
+	stream := ro != nil && ro.Stream
+	res, err := c.send(ctx, req, stream)
+	if c.shouldReauthenticate(spath, req, err) {
+		var r *http.Request
+		if r, err = c.reauthenticate(ctx, req); err != nil {
+			return nil, err
+		}
+		res, err = c.send(ctx, r, stream)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -127,9 +192,28 @@ func (c *Client) HTTP(verb, spath string, ro *RequestOptions) (*http.Response, e
 	return res, nil
 }
 
+// send sends request and returns response with dispose
+func (c *Client) send(ctx context.Context, req *http.Request, stream bool) (*http.Response, error) {
+	start := time.Now()
+	res, err := c.do(ctx, req)
+	if err == nil {
+		c.logger().Info("response", "verb", req.Method, "path", req.URL.Path, "status", res.StatusCode, "duration", time.Since(start))
+		if Replayed(res) {
+			c.logger().Info("replayed response", "verb", req.Method, "path", req.URL.Path, "idempotency_key", req.Header.Get(IdempotencyKeyHeader))
+		}
+	}
+
+	return c.dispose(ctx, res, err, stream)
+}
+
 // Request returns http.Request pointer with error
 func (c *Client) Request(verb, spath string, ro *RequestOptions) (*http.Request, error) {
-	log.Printf("[INFO] request: %s %s", verb, spath)
+	return c.RequestContext(context.Background(), verb, spath, ro)
+}
+
+// RequestContext returns http.Request pointer bound to ctx with error
+func (c *Client) RequestContext(ctx context.Context, verb, spath string, ro *RequestOptions) (*http.Request, error) {
+	c.logger().Info("request", "verb", verb, "path", spath)
 
 	if ro == nil {
 		ro = new(RequestOptions)
@@ -145,11 +229,31 @@ func (c *Client) Request(verb, spath string, ro *RequestOptions) (*http.Request,
 		ro.Headers["Authorization"] = fmt.Sprintf("Bearer %s", c.Token)
 	}
 
-	return c.rawRequest(verb, &u, ro)
+	req, err := c.rawRequest(ctx, verb, &u, ro)
+	if err != nil {
+		return nil, err
+	}
+
+	// the key is fixed here, so that retries and reauthentication reuse it
+	if req.Header.Get(IdempotencyKeyHeader) == "" {
+		key, err := idempotencyKey(ctx, verb, spath)
+		if err != nil {
+			return nil, err
+		}
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+	}
+
+	return req, nil
 }
 
 // rawRequest returns http.Request pointer with error
-func (c *Client) rawRequest(verb string, u *url.URL, ro *RequestOptions) (*http.Request, error) {
+func (c *Client) rawRequest(ctx context.Context, verb string, u *url.URL, ro *RequestOptions) (*http.Request, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("client: missing context")
+	}
+
 	if verb == "" {
 		return nil, fmt.Errorf("client: missing verb")
 	}
@@ -166,9 +270,14 @@ func (c *Client) rawRequest(verb string, u *url.URL, ro *RequestOptions) (*http.
 	for k, v := range ro.Params {
 		params.Add(k, v)
 	}
+	for k, vs := range ro.Query {
+		for _, v := range vs {
+			params.Add(k, v)
+		}
+	}
 	u.RawQuery = params.Encode()
 
-	request, err := http.NewRequest(verb, u.String(), ro.Body)
+	request, err := http.NewRequestWithContext(ctx, verb, u.String(), ro.Body)
 	if err != nil {
 		return nil, err
 	}
@@ -185,23 +294,38 @@ func (c *Client) rawRequest(verb string, u *url.URL, ro *RequestOptions) (*http.
 		request.ContentLength = ro.BodyLength
 	}
 
-	log.Printf("[DEBUG] raw request: %#v", request)
+	c.logger().Debug("raw request", "verb", request.Method, "url", request.URL.String(), "headers", request.Header)
 
 	return request, nil
 }
 
 // dispose returns http.Request pointer with error
-func dispose(res *http.Response, err error) (*http.Response, error) {
+func (c *Client) dispose(ctx context.Context, res *http.Response, err error, stream bool) (*http.Response, error) {
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return res, err
 	}
 
-	log.Printf("[INFO] response: %d (%s)", res.StatusCode, res.Status)
+	var spath string
+	if res.Request != nil {
+		spath = res.Request.URL.Path
+	}
+
+	if stream && res.StatusCode >= 200 && res.StatusCode < 300 {
+		return c.stream(res, spath), nil
+	}
+
 	var buf bytes.Buffer
 	if _, err := io.Copy(&buf, res.Body); err != nil {
-		log.Printf("[ERR] response: error copying response body")
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			res.Body.Close()
+			return nil, ctxErr
+		}
+		c.logger().Error("error copying response body", "path", spath, "error", err)
 	} else {
-		log.Printf("[DEBUG] response: %s", buf.String())
+		c.logger().Debug("response body", "path", spath, "body", buf.String())
 		res.Body.Close()
 		res.Body = &bytesReadCloser{&buf}
 	}
@@ -215,28 +339,33 @@ func dispose(res *http.Response, err error) (*http.Response, error) {
 		return res, nil
 	case 204:
 		return res, nil
-	case 400:
-		return nil, parseErr(res)
-	case 401:
-		return nil, fmt.Errorf("authentication failed")
-	case 404:
-		return nil, fmt.Errorf("resource not found")
-	case 422:
-		return nil, parseErr(res)
 	default:
-		return nil, fmt.Errorf("client: %s", res.Status)
+		return nil, parseErr(res)
 	}
 }
 
 // parseErr parses for error response
 func parseErr(r *http.Response) error {
-	re := &AppError{}
+	e := &APIError{
+		StatusCode: r.StatusCode,
+		Status:     r.Status,
+		RequestID:  r.Header.Get(requestIDHeader),
+	}
+	if r.StatusCode == http.StatusUnauthorized {
+		e.OTPRequired = strings.HasPrefix(strings.ToLower(r.Header.Get(OTPHeader)), "required")
+	}
+	if r.Request != nil {
+		e.Method = r.Request.Method
+		e.Path = r.Request.URL.Path
+	}
 
-	if err := decodeJSON(r, &re); err != nil {
-		return fmt.Errorf("error decoding JSON body: %s", err)
+	// error body is optional, so keep status only when it is not JSON
+	re := &AppError{}
+	if err := decodeJSON(r, &re); err == nil {
+		e.Errors = re.Errors
 	}
 
-	return re
+	return e
 }
 
 // decodeJSON decodes for response
@@ -256,7 +385,7 @@ func (nrc *bytesReadCloser) Close() error {
 	return nil
 }
 
-// AppError struct
+// AppError struct for errors array in response body
 type AppError struct {
 	Errors []string `json:"errors"`
 }
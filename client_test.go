@@ -2,11 +2,15 @@ package lolp
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"log"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestNew(t *testing.T) {
@@ -64,7 +68,15 @@ func TestClientInit(t *testing.T) {
 		DefaultHeader: make(http.Header),
 	}
 	c.init()
-	if !c.HTTPClient.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify {
+	if c.HTTPClient.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify {
+		t.Errorf("skip verify expects false regardless of environment variables")
+	}
+
+	cc, err := NewFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cc.HTTPClient.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify {
 		t.Errorf("skip verify expects true")
 	}
 }
@@ -93,3 +105,44 @@ func TestClientRequest(t *testing.T) {
 		t.Errorf("Authorization header is wrong: %s", a)
 	}
 }
+
+func TestClientRequestContext(t *testing.T) {
+	c, err := NewClient("https://api.example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := c.RequestContext(ctx, "GET", "/test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Context() != ctx {
+		t.Errorf("request context expects to be the given context")
+	}
+}
+
+func TestClientHTTPContextCanceled(t *testing.T) {
+	done := make(chan struct{})
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer s.Close()
+	defer close(done)
+
+	c, err := NewClient(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.HTTPContext(ctx, "GET", "/slow", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expects context deadline exceeded, but got %v", err)
+	}
+}
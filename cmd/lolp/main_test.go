@@ -2,13 +2,66 @@ package main
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pepabo/golipop"
+	"github.com/pepabo/golipop/lolptest"
 )
 
+// isolateEnv points credential sources of CLI into temporary directory and
+// unsets token variables, so that tests do not read credentials of the
+// developer, and returns function restoring them
+func isolateEnv(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "lolp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vars := map[string]string{
+		"LOLP_CREDENTIALS":        filepath.Join(dir, "credentials"),
+		"NETRC":                   filepath.Join(dir, "netrc"),
+		"LOLP_TOKEN":              "",
+		"LOLP_CREDENTIAL_PROCESS": "",
+		"LOLP_PROFILE":            "",
+	}
+	saved := make(map[string]*string)
+	for k, v := range vars {
+		if old, ok := os.LookupEnv(k); ok {
+			saved[k] = &old
+		} else {
+			saved[k] = nil
+		}
+		if v == "" {
+			os.Unsetenv(k)
+		} else {
+			os.Setenv(k, v)
+		}
+	}
+
+	return func() {
+		for k, v := range saved {
+			if v == nil {
+				os.Unsetenv(k)
+			} else {
+				os.Setenv(k, *v)
+			}
+		}
+		os.RemoveAll(dir)
+	}
+}
+
 func TestVersion(t *testing.T) {
 	out, err := new(bytes.Buffer), new(bytes.Buffer)
 
@@ -24,3 +77,399 @@ func TestVersion(t *testing.T) {
 		t.Fatalf("expected: \"%s\", actual: \"%s\"", expected, err.String())
 	}
 }
+
+func TestProjectListAll(t *testing.T) {
+	defer isolateEnv(t)()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			io.WriteString(w, `[{"id":"2","name":"php-1.lolipop.io","kind":"php"}]`)
+			return
+		}
+		w.Header().Set("Link", `<`+"http://"+r.Host+`/v1/projects?page=2>; rel="next"`)
+		io.WriteString(w, `[{"id":"1","name":"rails-1.lolipop.io","kind":"rails"}]`)
+	}))
+	defer s.Close()
+
+	os.Setenv("LOLP_ENDPOINT", s.URL)
+	os.Setenv("LOLP_CONFIG", "testdata/not-exist")
+	defer os.Unsetenv("LOLP_ENDPOINT")
+	defer os.Unsetenv("LOLP_CONFIG")
+
+	out, err := new(bytes.Buffer), new(bytes.Buffer)
+	cli := &CLI{outStream: out, errStream: err}
+	args := strings.Split("project list --all", " ")
+
+	if status := cli.run(args); status != ExitOK {
+		t.Fatalf("expected: \"%d\", actual: \"%d\": %s", ExitOK, status, err.String())
+	}
+
+	for _, name := range []string{"rails-1.lolipop.io", "php-1.lolipop.io"} {
+		if !strings.Contains(out.String(), name) {
+			t.Errorf("expected: \"%s\" in output, actual: \"%s\"", name, out.String())
+		}
+	}
+}
+
+func TestProjectListAllByPage(t *testing.T) {
+	defer isolateEnv(t)()
+
+	var requests int
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		n := limit
+		if r.URL.Query().Get("page") == "2" {
+			n = 1
+		}
+		var projects []string
+		for i := 0; i < n; i++ {
+			projects = append(projects, fmt.Sprintf(`{"id":"%s-%d","name":"php-%s-%d.lolipop.io","kind":"php"}`, r.URL.Query().Get("page"), i, r.URL.Query().Get("page"), i))
+		}
+		io.WriteString(w, "["+strings.Join(projects, ",")+"]")
+	}))
+	defer s.Close()
+
+	os.Setenv("LOLP_ENDPOINT", s.URL)
+	os.Setenv("LOLP_CONFIG", "testdata/not-exist")
+	defer os.Unsetenv("LOLP_ENDPOINT")
+	defer os.Unsetenv("LOLP_CONFIG")
+
+	out, err := new(bytes.Buffer), new(bytes.Buffer)
+	cli := &CLI{outStream: out, errStream: err}
+	args := strings.Split("project list --all", " ")
+
+	if status := cli.run(args); status != ExitOK {
+		t.Fatalf("expected: \"%d\", actual: \"%d\": %s", ExitOK, status, err.String())
+	}
+	if requests != 2 {
+		t.Errorf("expected: 2 requests, actual: %d", requests)
+	}
+	if !strings.Contains(out.String(), "php-2-0.lolipop.io") {
+		t.Errorf("expected: project of page 2 in output, actual: \"%s\"", out.String())
+	}
+}
+
+func TestProfile(t *testing.T) {
+	defer isolateEnv(t)()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer staging-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		io.WriteString(w, `[{"id":"1","name":"rails-1.lolipop.io","kind":"rails"}]`)
+	}))
+	defer s.Close()
+
+	dir, e := ioutil.TempDir("", "lolp")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	config := filepath.Join(dir, "config")
+	in := fmt.Sprintf("[production]\nendpoint = https://api.mc.lolipop.jp/\n\n[staging]\nendpoint = %s\ntoken = staging-token\noutput = json\n", s.URL)
+	if e := ioutil.WriteFile(config, []byte(in), 0600); e != nil {
+		t.Fatal(e)
+	}
+	os.Setenv("LOLP_CONFIG", config)
+	os.Setenv("LOLP_CREDENTIALS", filepath.Join(dir, "credentials"))
+	defer os.Unsetenv("LOLP_CONFIG")
+	defer os.Unsetenv("LOLP_CREDENTIALS")
+
+	out, err := new(bytes.Buffer), new(bytes.Buffer)
+	cli := &CLI{outStream: out, errStream: err}
+	if status := cli.run(strings.Split("--profile staging project list", " ")); status != ExitOK {
+		t.Fatalf("expected: \"%d\", actual: \"%d\": %s", ExitOK, status, err.String())
+	}
+	if !strings.Contains(out.String(), `"name": "rails-1.lolipop.io"`) {
+		t.Errorf("expected: project in JSON, actual: \"%s\"", out.String())
+	}
+
+	out, err = new(bytes.Buffer), new(bytes.Buffer)
+	cli = &CLI{outStream: out, errStream: err}
+	if status := cli.run(strings.Split("profile use staging", " ")); status != ExitOK {
+		t.Fatalf("expected: \"%d\", actual: \"%d\": %s", ExitOK, status, err.String())
+	}
+
+	out, err = new(bytes.Buffer), new(bytes.Buffer)
+	cli = &CLI{outStream: out, errStream: err}
+	if status := cli.run(strings.Split("profile list -o text", " ")); status != ExitOK {
+		t.Fatalf("expected: \"%d\", actual: \"%d\": %s", ExitOK, status, err.String())
+	}
+	if !strings.Contains(out.String(), "* staging") || !strings.Contains(out.String(), "  production") {
+		t.Errorf("expected: staging marked as current, actual: \"%s\"", out.String())
+	}
+
+	out, err = new(bytes.Buffer), new(bytes.Buffer)
+	cli = &CLI{outStream: out, errStream: err}
+	if status := cli.run(strings.Split("profile show", " ")); status != ExitOK {
+		t.Fatalf("expected: \"%d\", actual: \"%d\": %s", ExitOK, status, err.String())
+	}
+	if strings.Contains(out.String(), "staging-token") {
+		t.Errorf("expected: token masked, actual: \"%s\"", out.String())
+	}
+}
+
+func TestAuthStatus(t *testing.T) {
+	defer isolateEnv(t)()
+
+	os.Setenv("LOLP_CONFIG", "testdata/not-exist")
+	defer os.Unsetenv("LOLP_CONFIG")
+
+	jwt := func(exp time.Time) string {
+		enc := base64.RawURLEncoding
+		claims := fmt.Sprintf(`{"sub":"foo@example.com","exp":%d}`, exp.Unix())
+		return enc.EncodeToString([]byte(`{"alg":"HS256"}`)) + "." + enc.EncodeToString([]byte(claims)) + ".sig"
+	}
+
+	cases := []struct {
+		token    string
+		expected int
+		message  string
+	}{
+		{jwt(time.Now().Add(72 * time.Hour)), ExitOK, ""},
+		{jwt(time.Now().Add(time.Hour)), ExitErr, "token expires in"},
+		{jwt(time.Now().Add(-time.Hour)), ExitErr, "token expired at"},
+		{"opaque", ExitOK, "expiry is unknown"},
+	}
+
+	for _, cc := range cases {
+		out, err := new(bytes.Buffer), new(bytes.Buffer)
+		cli := &CLI{outStream: out, errStream: err}
+		args := []string{"--token", cc.token, "auth", "status"}
+
+		if status := cli.run(args); status != cc.expected {
+			t.Errorf("expected: \"%d\", actual: \"%d\": %s", cc.expected, status, err.String())
+		}
+		if !strings.Contains(err.String(), cc.message) {
+			t.Errorf("expected: \"%s\", actual: \"%s\"", cc.message, err.String())
+		}
+	}
+
+	enc := base64.RawURLEncoding
+	token := enc.EncodeToString([]byte(`{"alg":"HS256"}`)) + "." + enc.EncodeToString([]byte(`{"sub":"foo@example.com"}`)) + ".sig"
+	for _, output := range []string{"text", "json"} {
+		out, err := new(bytes.Buffer), new(bytes.Buffer)
+		cli := &CLI{outStream: out, errStream: err}
+		if status := cli.run([]string{"--token", token, "-o", output, "auth", "status"}); status != ExitOK {
+			t.Errorf("expected: \"%d\", actual: \"%d\": %s", ExitOK, status, err.String())
+		}
+		if !strings.Contains(out.String(), "foo@example.com") || strings.Contains(out.String(), "0001-01-01") {
+			t.Errorf("expected: zero times left out, actual: \"%s\"", out.String())
+		}
+	}
+}
+
+func TestLoginWithOTP(t *testing.T) {
+	defer isolateEnv(t)()
+
+	s := lolptest.NewServer()
+	defer s.Close()
+	s.AddUser("foo@example.com", "Secret#Gopher123?")
+	s.EnableOTP("foo@example.com", "123456")
+
+	os.Setenv("LOLP_ENDPOINT", s.URL)
+	os.Setenv("LOLP_CONFIG", "testdata/not-exist")
+	defer os.Unsetenv("LOLP_ENDPOINT")
+	defer os.Unsetenv("LOLP_CONFIG")
+
+	cases := []struct {
+		args     string
+		input    string
+		expected int
+	}{
+		{"login -u foo@example.com -p Secret#Gopher123? --otp 123456", "", ExitOK},
+		{"login -u foo@example.com -p Secret#Gopher123?", "123456\n", ExitOK},
+		{"login -u foo@example.com -p Secret#Gopher123?", "", ExitErr},
+		{"login -u foo@example.com -p Secret#Gopher123? --otp 000000", "123456\n", ExitErr},
+	}
+
+	for _, cc := range cases {
+		out, err := new(bytes.Buffer), new(bytes.Buffer)
+		cli := &CLI{inStream: strings.NewReader(cc.input), outStream: out, errStream: err}
+
+		if status := cli.run(strings.Split(cc.args, " ")); status != cc.expected {
+			t.Errorf("%s with input %q\nexpected: \"%d\", actual: \"%d\": %s", cc.args, cc.input, cc.expected, status, err.String())
+		}
+		if cc.expected == ExitOK && !strings.Contains(out.String(), "export LOLP_TOKEN=") {
+			t.Errorf("expected: token exported, actual: \"%s\"", out.String())
+		}
+		if cc.expected == ExitErr && !strings.Contains(err.String(), "one-time password required") {
+			t.Errorf("expected: otp required error, actual: \"%s\"", err.String())
+		}
+	}
+}
+
+func TestLogout(t *testing.T) {
+	defer isolateEnv(t)()
+
+	s := lolptest.NewServer()
+	defer s.Close()
+	token := s.IssueToken("foo@example.com")
+
+	dir, e := ioutil.TempDir("", "lolp")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	credentials := filepath.Join(dir, "credentials")
+	if e := ioutil.WriteFile(credentials, []byte("token = "+token+"\n"), 0600); e != nil {
+		t.Fatal(e)
+	}
+	os.Setenv("LOLP_ENDPOINT", s.URL)
+	os.Setenv("LOLP_CONFIG", "testdata/not-exist")
+	os.Setenv("LOLP_CREDENTIALS", credentials)
+	defer os.Unsetenv("LOLP_ENDPOINT")
+	defer os.Unsetenv("LOLP_CONFIG")
+	defer os.Unsetenv("LOLP_CREDENTIALS")
+
+	out, err := new(bytes.Buffer), new(bytes.Buffer)
+	cli := &CLI{outStream: out, errStream: err}
+	if status := cli.run([]string{"logout"}); status != ExitOK {
+		t.Fatalf("expected: \"%d\", actual: \"%d\": %s", ExitOK, status, err.String())
+	}
+	if b, _ := ioutil.ReadFile(credentials); strings.Contains(string(b), token) {
+		t.Errorf("expected: token removed from credentials file, actual: \"%s\"", b)
+	}
+
+	c, _ := s.NewClient(lolp.WithToken(token))
+	if _, e := c.Projects(); e == nil {
+		t.Errorf("expected: token revoked")
+	}
+
+	saved := s.IssueToken("foo@example.com")
+	if e := ioutil.WriteFile(credentials, []byte("token = "+saved+"\n"), 0600); e != nil {
+		t.Fatal(e)
+	}
+	out, err = new(bytes.Buffer), new(bytes.Buffer)
+	cli = &CLI{outStream: out, errStream: err}
+	if status := cli.run([]string{"--token", s.IssueToken("foo@example.com"), "logout"}); status != ExitOK {
+		t.Fatalf("expected: \"%d\", actual: \"%d\": %s", ExitOK, status, err.String())
+	}
+	if b, _ := ioutil.ReadFile(credentials); !strings.Contains(string(b), saved) {
+		t.Errorf("expected: another saved token kept, actual: \"%s\"", b)
+	}
+
+	token = s.IssueToken("foo@example.com")
+	if e := ioutil.WriteFile(credentials, []byte("token = "+token+"\nusername = foo@example.com\n"), 0600); e != nil {
+		t.Fatal(e)
+	}
+	os.Setenv("LOLP_ENDPOINT", "http://127.0.0.1:1")
+	out, err = new(bytes.Buffer), new(bytes.Buffer)
+	cli = &CLI{outStream: out, errStream: err}
+	if status := cli.run([]string{"logout"}); status != ExitErr {
+		t.Fatalf("expected: \"%d\", actual: \"%d\"", ExitErr, status)
+	}
+	if !strings.Contains(err.String(), "may still be valid") {
+		t.Errorf("expected: guidance on failure, actual: \"%s\"", err.String())
+	}
+	if b, _ := ioutil.ReadFile(credentials); !strings.Contains(string(b), token) || !strings.Contains(string(b), "foo@example.com") {
+		t.Errorf("expected: credentials kept to retry logout, actual: \"%s\"", b)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	defer isolateEnv(t)()
+
+	s := lolptest.NewServer()
+	defer s.Close()
+	s.AddProject(lolp.Project{SubDomain: "foo", Kind: "php"})
+
+	os.Setenv("LOLP_ENDPOINT", s.URL)
+	os.Setenv("LOLP_CONFIG", "testdata/not-exist")
+	defer os.Unsetenv("LOLP_ENDPOINT")
+	defer os.Unsetenv("LOLP_CONFIG")
+
+	out, err := new(bytes.Buffer), new(bytes.Buffer)
+	cli := &CLI{outStream: out, errStream: err}
+	args := []string{"--token", s.IssueToken("foo@example.com"), "--dry-run", "project", "delete", "foo"}
+
+	if status := cli.run(args); status != ExitOK {
+		t.Fatalf("expected: \"%d\", actual: \"%d\": %s", ExitOK, status, err.String())
+	}
+	if out.String() != "DELETE /v1/projects/foo\n" {
+		t.Errorf("expected: request printed, actual: \"%s\"", out.String())
+	}
+	if len(s.Projects()) != 1 {
+		t.Errorf("expected: project kept in dry run")
+	}
+}
+
+func TestAPI(t *testing.T) {
+	defer isolateEnv(t)()
+
+	s := lolptest.NewServer()
+	defer s.Close()
+	s.AddProject(lolp.Project{SubDomain: "foo", Kind: "php"})
+	token := s.IssueToken("foo@example.com")
+
+	os.Setenv("LOLP_ENDPOINT", s.URL)
+	os.Setenv("LOLP_CONFIG", "testdata/not-exist")
+	defer os.Unsetenv("LOLP_ENDPOINT")
+	defer os.Unsetenv("LOLP_CONFIG")
+
+	cases := []struct {
+		args     string
+		input    string
+		expected int
+		output   string
+	}{
+		{"api get /v1/projects/foo", "", ExitOK, "{\n  \"id\": "},
+		{"api POST v1/projects -f kind=php -f sub_domain=bar -f db_password=Secret#Gopher123?", "", ExitOK, `"domain": "bar.lolipop.io"`},
+		{"api PUT /v1/projects/bar/environment-variables --input -", `[{"method":"create","variable":{"key":"FOO","value":"1"}}]`, ExitOK, ""},
+		{"api GET /v1/projects/bar/environment-variables", "", ExitOK, `"FOO": "1"`},
+		{"api PUT /v1/projects/bar/environment-variables --input -", `not json`, ExitErr, ""},
+		{"api PUT /v1/projects/bar/environment-variables --input - -f key=FOO", `[]`, ExitErr, ""},
+		{"api POST v1/projects -f kind=php -f kind=node", "", ExitErr, ""},
+		{"api GET /v1/projects/unknown", "", ExitErr, ""},
+		{"api GET", "", ExitErr, ""},
+	}
+
+	for _, cc := range cases {
+		out, err := new(bytes.Buffer), new(bytes.Buffer)
+		cli := &CLI{inStream: strings.NewReader(cc.input), outStream: out, errStream: err}
+		args := append([]string{"--token", token}, strings.Split(cc.args, " ")...)
+
+		if status := cli.run(args); status != cc.expected {
+			t.Errorf("%s\nexpected: \"%d\", actual: \"%d\": %s", cc.args, cc.expected, status, err.String())
+		}
+		if !strings.Contains(out.String(), cc.output) {
+			t.Errorf("%s\nexpected: \"%s\" in output, actual: \"%s\"", cc.args, cc.output, out.String())
+		}
+	}
+
+	if vars := s.EnvironmentVariables("bar"); vars["FOO"] != "1" {
+		t.Errorf("expected: environment variable created, actual: %v", vars)
+	}
+}
+
+func TestAPIQuery(t *testing.T) {
+	defer isolateEnv(t)()
+
+	var query url.Values
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, "[]")
+	}))
+	defer s.Close()
+
+	os.Setenv("LOLP_ENDPOINT", s.URL)
+	os.Setenv("LOLP_CONFIG", "testdata/not-exist")
+	defer os.Unsetenv("LOLP_ENDPOINT")
+	defer os.Unsetenv("LOLP_CONFIG")
+
+	out, err := new(bytes.Buffer), new(bytes.Buffer)
+	cli := &CLI{outStream: out, errStream: err}
+	args := []string{"--token", "foo", "api", "GET", "/v1/projects?kind=php&kind=rails", "-f", "kind=node", "-f", "limit=2"}
+
+	if status := cli.run(args); status != ExitOK {
+		t.Fatalf("expected: \"%d\", actual: \"%d\": %s", ExitOK, status, err.String())
+	}
+	if kinds := strings.Join(query["kind"], ","); kinds != "php,rails,node" || query.Get("limit") != "2" {
+		t.Errorf("expected: every query value kept, actual: \"%s\"", query.Encode())
+	}
+}
@@ -0,0 +1,98 @@
+package lolp
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseINI(t *testing.T) {
+	in := `# comment
+proxy = http://proxy.example.com:8080
+; comment
+[staging]
+endpoint = https://api.staging.example.com/
+`
+	sections, err := parseINI(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := sections[""]["proxy"]; v != "http://proxy.example.com:8080" {
+		t.Errorf("proxy is wrong: %s", v)
+	}
+	if v := sections["staging"]["endpoint"]; v != "https://api.staging.example.com/" {
+		t.Errorf("endpoint of staging is wrong: %s", v)
+	}
+
+	if _, err := parseINI(strings.NewReader("proxy")); err == nil {
+		t.Errorf("line without '=' expects error")
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lolp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cf, err := LoadConfig(filepath.Join(dir, "not-exist"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cf.Options()) != 0 {
+		t.Errorf("config of missing file expects no options")
+	}
+
+	p := filepath.Join(dir, "config")
+	if err := ioutil.WriteFile(p, []byte("proxy = http://proxy.example.com:8080\nno_proxy = localhost\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cf, err = LoadConfig(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cf.Proxy != "http://proxy.example.com:8080" || cf.NoProxy != "localhost" {
+		t.Errorf("config is wrong: %#v", cf)
+	}
+
+	os.Setenv(ConfigEnvVar, p)
+	defer os.Unsetenv(ConfigEnvVar)
+	if DefaultConfigPath() != p {
+		t.Errorf("config path expects %s, but got %s", p, DefaultConfigPath())
+	}
+}
+
+func TestDefaultConfigPathWithoutHome(t *testing.T) {
+	for _, k := range []string{ConfigEnvVar, CredentialsEnvVar, "XDG_CONFIG_HOME", "HOME"} {
+		if v, ok := os.LookupEnv(k); ok {
+			defer os.Setenv(k, v)
+		}
+		os.Unsetenv(k)
+	}
+
+	if p := DefaultConfigPath(); p != "" {
+		t.Errorf("config path without home expects empty, but got %s", p)
+	}
+	if p := DefaultCredentialsPath(); p != "" {
+		t.Errorf("credentials path without home expects empty, but got %s", p)
+	}
+
+	cf, err := LoadConfig(DefaultConfigPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cf.Profiles) != 0 {
+		t.Errorf("config without home expects to be empty: %#v", cf)
+	}
+
+	if _, err := FileCredentials("", "").Retrieve(context.Background()); err != ErrNoCredentials {
+		t.Errorf("credentials without home expects ErrNoCredentials, but got %v", err)
+	}
+	if err := SaveCredentials("", "", &Credentials{Token: "foo"}); err != ErrNoConfigDir {
+		t.Errorf("saving credentials without home expects ErrNoConfigDir, but got %v", err)
+	}
+}
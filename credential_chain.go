@@ -0,0 +1,334 @@
+package lolp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// CredentialsEnvVar for path of credentials file
+	CredentialsEnvVar = "LOLP_CREDENTIALS"
+
+	// CredentialProcessEnvVar for command printing credentials
+	CredentialProcessEnvVar = "LOLP_CREDENTIAL_PROCESS"
+
+	// defaultProfile in credentials file
+	defaultProfile = "default"
+)
+
+// ErrNoCredentials is returned by provider which has no credentials, so
+// that ChainCredentials tries next one
+var ErrNoCredentials = errors.New("no credentials")
+
+// StaticCredentials returns provider of token
+func StaticCredentials(token string) CredentialProvider {
+	return CredentialProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		if token == "" {
+			return nil, ErrNoCredentials
+		}
+		return &Credentials{Token: token}, nil
+	})
+}
+
+// EnvCredentials returns provider of token in LOLP_TOKEN
+func EnvCredentials() CredentialProvider {
+	return CredentialProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		return StaticCredentials(os.Getenv(TokenEnvVar)).Retrieve(ctx)
+	})
+}
+
+// DefaultCredentialsPath returns path of credentials file by
+// LOLP_CREDENTIALS, or lolp/credentials in user config directory. It returns
+// empty when user config directory is not resolved.
+func DefaultCredentialsPath() string {
+	if p := os.Getenv(CredentialsEnvVar); p != "" {
+		return p
+	}
+	return configPath("credentials")
+}
+
+// FileCredentials returns provider of credentials file in INI format,
+// where section is profile and keys outside sections are default profile:
+//
+//	[default]
+//	token = ...
+//
+//	[customer]
+//	username = foo@example.com
+//	password = ...
+//
+//	[vault]
+//	credential_process = vault read -field=token secret/lolp
+//
+// Empty path and profile are the default ones.
+func FileCredentials(path, profile string) CredentialProvider {
+	return CredentialProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		p := path
+		if p == "" {
+			p = DefaultCredentialsPath()
+		}
+		sections, err := readINI(p)
+		if err != nil {
+			return nil, err
+		}
+
+		kv := profileSection(sections, profile)
+		switch {
+		case kv["token"] != "":
+			return &Credentials{Token: kv["token"]}, nil
+		case kv["username"] != "":
+			return &Credentials{Username: kv["username"], Password: kv["password"]}, nil
+		case kv["credential_process"] != "":
+			return ProcessCredentials(kv["credential_process"]).Retrieve(ctx)
+		default:
+			return nil, ErrNoCredentials
+		}
+	})
+}
+
+// profileSection returns section of profile, where default profile falls
+// back to keys outside sections
+func profileSection(sections map[string]map[string]string, profile string) map[string]string {
+	if profile == "" {
+		profile = defaultProfile
+	}
+	if kv, ok := sections[profile]; ok {
+		return kv
+	}
+	if profile == defaultProfile {
+		return sections[""]
+	}
+	return nil
+}
+
+// SaveCredentials writes credentials of profile into credentials file,
+// keeping other profiles. Empty path and profile are the default ones.
+func SaveCredentials(path, profile string, creds *Credentials) error {
+	if path == "" {
+		path = DefaultCredentialsPath()
+	}
+	if profile == "" {
+		profile = defaultProfile
+	}
+
+	sections, err := readINI(path)
+	if err != nil {
+		return err
+	}
+	if profile == defaultProfile {
+		delete(sections, "")
+	}
+
+	kv := make(map[string]string)
+	if creds.Token != "" {
+		kv["token"] = creds.Token
+	}
+	if creds.Username != "" {
+		kv["username"] = creds.Username
+		kv["password"] = creds.Password
+	}
+	sections[profile] = kv
+
+	return writeINI(path, sections)
+}
+
+// RemoveCredentials deletes token of profile from credentials file when it
+// equals token, keeping username, password and credential process. Empty
+// path and profile are the default ones.
+func RemoveCredentials(path, profile, token string) error {
+	if path == "" {
+		path = DefaultCredentialsPath()
+	}
+
+	sections, err := readINI(path)
+	if err != nil {
+		return err
+	}
+	kv := profileSection(sections, profile)
+	if t, ok := kv["token"]; !ok || t != token {
+		return nil
+	}
+	delete(kv, "token")
+
+	return writeINI(path, sections)
+}
+
+// NetrcCredentials returns provider of login and password for host in
+// netrc file, which is ~/.netrc when path is empty
+func NetrcCredentials(path, host string) CredentialProvider {
+	return CredentialProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		p := path
+		if p == "" {
+			p = os.Getenv("NETRC")
+		}
+		if p == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, ErrNoCredentials
+			}
+			p = filepath.Join(home, ".netrc")
+		}
+
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, ErrNoCredentials
+			}
+			return nil, err
+		}
+
+		login, password, ok := parseNetrc(b, host)
+		if !ok {
+			return nil, ErrNoCredentials
+		}
+
+		return &Credentials{Username: login, Password: password}, nil
+	})
+}
+
+// parseNetrc returns login and password of machine, or of default entry
+func parseNetrc(b []byte, host string) (string, string, bool) {
+	var words []string
+	inMacro := false
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if inMacro {
+			inMacro = len(fields) > 0
+			continue
+		}
+		for i, f := range fields {
+			if f == "macdef" {
+				inMacro = true
+				fields = fields[:i]
+				break
+			}
+		}
+		words = append(words, fields...)
+	}
+
+	// entries by machine, where "" is default
+	entries := make(map[string]*Credentials)
+	var current *Credentials
+	for i := 0; i < len(words); i++ {
+		next := ""
+		if i+1 < len(words) {
+			next = words[i+1]
+		}
+		switch words[i] {
+		case "machine":
+			current = &Credentials{}
+			if _, ok := entries[next]; !ok {
+				entries[next] = current
+			}
+			i++
+		case "default":
+			current = &Credentials{}
+			entries[""] = current
+		case "login":
+			if current != nil {
+				current.Username = next
+			}
+			i++
+		case "password":
+			if current != nil {
+				current.Password = next
+			}
+			i++
+		}
+	}
+
+	for _, k := range []string{host, ""} {
+		if e, ok := entries[k]; ok && e.Username != "" {
+			return e.Username, e.Password, true
+		}
+	}
+
+	return "", "", false
+}
+
+// ProcessCredentials returns provider running command by shell, which
+// prints token, or JSON with "token" or "username" and "password"
+func ProcessCredentials(command string) CredentialProvider {
+	return CredentialProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		if command == "" {
+			return nil, ErrNoCredentials
+		}
+
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, "sh", "-c", command)
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("credential process: %s: %s", err, strings.TrimSpace(stderr.String()))
+		}
+
+		out = bytes.TrimSpace(out)
+		if !bytes.HasPrefix(out, []byte("{")) {
+			return StaticCredentials(string(out)).Retrieve(ctx)
+		}
+
+		var v struct {
+			Token    string `json:"token"`
+			Username string `json:"username"`
+			Password string `json:"password"`
+		}
+		if err := json.Unmarshal(out, &v); err != nil {
+			return nil, fmt.Errorf("credential process: %s", err)
+		}
+		if v.Token == "" && v.Username == "" {
+			return nil, ErrNoCredentials
+		}
+
+		return &Credentials{Token: v.Token, Username: v.Username, Password: v.Password}, nil
+	})
+}
+
+// ChainCredentials returns provider trying providers in order, and skips
+// one returning ErrNoCredentials
+func ChainCredentials(providers ...CredentialProvider) CredentialProvider {
+	return CredentialProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		for _, p := range providers {
+			creds, err := p.Retrieve(ctx)
+			if errors.Is(err, ErrNoCredentials) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			return creds, nil
+		}
+		return nil, ErrNoCredentials
+	})
+}
+
+// DefaultCredentialChain returns provider trying LOLP_TOKEN, credentials
+// file, LOLP_CREDENTIAL_PROCESS and netrc entry for host in order
+func DefaultCredentialChain(host string) CredentialProvider {
+	return ChainCredentials(
+		EnvCredentials(),
+		FileCredentials("", ""),
+		CredentialProviderFunc(func(ctx context.Context) (*Credentials, error) {
+			return ProcessCredentials(os.Getenv(CredentialProcessEnvVar)).Retrieve(ctx)
+		}),
+		NetrcCredentials("", host),
+	)
+}
+
+// WithDefaultCredentials sets DefaultCredentialChain for host of client,
+// and tries explicit token first when given
+func WithDefaultCredentials(token string) Option {
+	return func(c *Client) error {
+		c.Credentials = ChainCredentials(StaticCredentials(token), DefaultCredentialChain(c.URL.Hostname()))
+		return nil
+	}
+}
@@ -0,0 +1,154 @@
+package lolp
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lolp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := filepath.Join(dir, "credentials")
+	in := "token = top-level\n\n[customer]\nusername = foo@example.com\npassword = Secret#Gopher123?\n\n[process]\ncredential_process = echo '{\"token\":\"from-process\"}'\n"
+	if err := ioutil.WriteFile(p, []byte(in), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		profile  string
+		expected Credentials
+	}{
+		{"", Credentials{Token: "top-level"}},
+		{"customer", Credentials{Username: "foo@example.com", Password: "Secret#Gopher123?"}},
+		{"process", Credentials{Token: "from-process"}},
+	}
+	for _, cc := range cases {
+		creds, err := FileCredentials(p, cc.profile).Retrieve(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *creds != cc.expected {
+			t.Errorf("profile %q credentials\nexpected: %#v\nactual: %#v", cc.profile, cc.expected, *creds)
+		}
+	}
+
+	if _, err := FileCredentials(p, "unknown").Retrieve(context.Background()); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("unknown profile expects no credentials, but got %v", err)
+	}
+
+	if err := SaveCredentials(p, "", &Credentials{Token: "saved"}); err != nil {
+		t.Fatal(err)
+	}
+	creds, err := FileCredentials(p, "").Retrieve(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.Token != "saved" {
+		t.Errorf("saved token expects to be retrieved, but got %#v", creds)
+	}
+	if creds, err := FileCredentials(p, "customer").Retrieve(context.Background()); err != nil || creds.Username != "foo@example.com" {
+		t.Errorf("other profiles expect to be kept, but got %#v, %v", creds, err)
+	}
+	if fi, err := os.Stat(p); err != nil || fi.Mode().Perm() != 0600 {
+		t.Errorf("credentials file expects to be readable only by owner")
+	}
+
+	if err := RemoveCredentials(p, "", "other"); err != nil {
+		t.Fatal(err)
+	}
+	if creds, err := FileCredentials(p, "").Retrieve(context.Background()); err != nil || creds.Token != "saved" {
+		t.Errorf("another token expects to be kept, but got %#v, %v", creds, err)
+	}
+	if err := RemoveCredentials(p, "", "saved"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FileCredentials(p, "").Retrieve(context.Background()); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("removed token expects no credentials, but got %v", err)
+	}
+	if creds, err := FileCredentials(p, "customer").Retrieve(context.Background()); err != nil || creds.Username != "foo@example.com" {
+		t.Errorf("other profiles expect to be kept on removal, but got %#v, %v", creds, err)
+	}
+
+	if err := ioutil.WriteFile(p, []byte("[mixed]\ntoken = t\nusername = foo@example.com\npassword = p\ncredential_process = echo x\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveCredentials(p, "mixed", "t"); err != nil {
+		t.Fatal(err)
+	}
+	sections, err := readINI(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kv := sections["mixed"]; kv["token"] != "" || kv["username"] != "foo@example.com" || kv["password"] != "p" || kv["credential_process"] != "echo x" {
+		t.Errorf("removal expects to delete only token, but got %v", kv)
+	}
+}
+
+func TestParseNetrc(t *testing.T) {
+	in := `machine example.com login other password nope
+macdef init
+cd /pub
+machine ignored login macro
+
+machine api.mc.lolipop.jp
+  login foo@example.com
+  password Secret#Gopher123?
+default login anonymous password guest
+`
+	login, password, ok := parseNetrc([]byte(in), "api.mc.lolipop.jp")
+	if !ok || login != "foo@example.com" || password != "Secret#Gopher123?" {
+		t.Errorf("netrc entry is wrong: %s, %s", login, password)
+	}
+
+	login, _, ok = parseNetrc([]byte(in), "unknown.example.com")
+	if !ok || login != "anonymous" {
+		t.Errorf("netrc default entry expects to be used, but got %s", login)
+	}
+
+	if login, _, _ := parseNetrc([]byte(in), "ignored"); login == "macro" {
+		t.Errorf("machine in macro expects to be ignored")
+	}
+}
+
+func TestProcessCredentials(t *testing.T) {
+	creds, err := ProcessCredentials("echo plain-token").Retrieve(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.Token != "plain-token" {
+		t.Errorf("token expects plain-token, but got %s", creds.Token)
+	}
+
+	if _, err := ProcessCredentials("echo broken >&2; exit 1").Retrieve(context.Background()); err == nil || errors.Is(err, ErrNoCredentials) {
+		t.Errorf("failed process expects error, but got %v", err)
+	}
+}
+
+func TestChainCredentials(t *testing.T) {
+	failing := CredentialProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		return nil, errors.New("broken")
+	})
+
+	creds, err := ChainCredentials(StaticCredentials(""), StaticCredentials("second"), failing).Retrieve(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.Token != "second" {
+		t.Errorf("chain expects to skip provider without credentials, but got %#v", creds)
+	}
+
+	if _, err := ChainCredentials(failing, StaticCredentials("second")).Retrieve(context.Background()); err == nil {
+		t.Errorf("chain expects to stop at error")
+	}
+	if _, err := ChainCredentials(StaticCredentials("")).Retrieve(context.Background()); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("chain without credentials expects ErrNoCredentials, but got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package lolp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// authenticatePath for issuing token
+const authenticatePath = "/v1/authenticate"
+
+// Credentials struct for authorization, which has either token or
+// username and password to authenticate
+type Credentials struct {
+	Token    string
+	Username string
+	Password string
+}
+
+// CredentialProvider interface retrieves credentials, and is called again
+// when the token is rejected
+type CredentialProvider interface {
+	Retrieve(ctx context.Context) (*Credentials, error)
+}
+
+// CredentialProviderFunc adapts function to CredentialProvider
+type CredentialProviderFunc func(ctx context.Context) (*Credentials, error)
+
+// Retrieve calls f(ctx)
+func (f CredentialProviderFunc) Retrieve(ctx context.Context) (*Credentials, error) {
+	return f(ctx)
+}
+
+// PasswordCredentials returns provider of username and password, which
+// lets client authenticate again when the token expires
+func PasswordCredentials(username, password string) CredentialProvider {
+	return CredentialProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		return &Credentials{Username: username, Password: password}, nil
+	})
+}
+
+// WithCredentialProvider sets provider to obtain token before the first
+// request and again on 401 Unauthorized
+func WithCredentialProvider(p CredentialProvider) Option {
+	return func(c *Client) error {
+		c.Credentials = p
+		return nil
+	}
+}
+
+// prepareToken obtains token by provider when client has no token
+func (c *Client) prepareToken(ctx context.Context, spath string) error {
+	if c.Credentials == nil || c.Token != "" || isAuthenticatePath(spath) {
+		return nil
+	}
+
+	// without credentials, the API decides whether the request needs token
+	if err := c.refreshToken(ctx, ""); err != nil && !errors.Is(err, ErrNoCredentials) {
+		return err
+	}
+
+	return nil
+}
+
+// existingToken returns token of client, or token of credential provider
+// without authentication by username and password
+func (c *Client) existingToken(ctx context.Context) (string, error) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	if c.Credentials == nil || c.Token != "" {
+		return c.Token, nil
+	}
+
+	creds, err := c.Credentials.Retrieve(ctx)
+	if errors.Is(err, ErrNoCredentials) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("client: retrieving credentials: %w", err)
+	}
+	c.Token = creds.Token
+
+	return c.Token, nil
+}
+
+// shouldReauthenticate returns whether request failed by rejected token,
+// and can be sent again with new token
+func (c *Client) shouldReauthenticate(spath string, req *http.Request, err error) bool {
+	if c.Credentials == nil || isAuthenticatePath(spath) || !errors.Is(err, ErrUnauthorized) {
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// reauthenticate refreshes token, and returns request to send again
+func (c *Client) reauthenticate(ctx context.Context, req *http.Request) (*http.Request, error) {
+	rejected := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	c.logger().Info("reauthenticate", "verb", req.Method, "path", req.URL.Path)
+	if err := c.refreshToken(ctx, rejected); err != nil {
+		return nil, err
+	}
+
+	r, err := rewind(req)
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+
+	return r, nil
+}
+
+// refreshToken obtains new token by provider, unless another request has
+// already replaced the rejected token
+func (c *Client) refreshToken(ctx context.Context, rejected string) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	if c.Token != rejected {
+		return nil
+	}
+
+	creds, err := c.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("client: retrieving credentials: %w", err)
+	}
+
+	switch {
+	case creds.Username != "" || creds.Password != "":
+		_, err = c.AuthenticateContext(ctx, creds.Username, creds.Password)
+		return err
+	case creds.Token != "":
+		c.Token = creds.Token
+		return nil
+	default:
+		return ErrNoCredentials
+	}
+}
+
+// isAuthenticatePath returns whether path is for authentication
+func isAuthenticatePath(spath string) bool {
+	return strings.TrimSuffix(spath, "/") == authenticatePath
+}
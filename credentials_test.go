@@ -0,0 +1,130 @@
+package lolp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func rotatingTokenHandler(t *testing.T, valid *string, issued *int) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			panic(err.Error())
+		}
+
+		if r.URL.Path == "/v1/authenticate" {
+			*issued++
+			*valid = fmt.Sprintf("token-%d", *issued)
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(*valid)
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer "+*valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			io.WriteString(w, `{"errors":["Token expired"]}`)
+			return
+		}
+		if r.Method == "PUT" && string(body) != `[]` {
+			t.Errorf("request body is not rewound: %s", body)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestReauthenticate(t *testing.T) {
+	var valid string
+	var issued int
+	s := httptest.NewServer(http.HandlerFunc(rotatingTokenHandler(t, &valid, &issued)))
+	defer s.Close()
+
+	c, err := NewClient(s.URL, WithCredentialProvider(PasswordCredentials("foo@example.com", "Secret#Gopher123?")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.DeleteProject("rails-1"); err != nil {
+		t.Fatal(err)
+	}
+	if issued != 1 || c.Token != "token-1" {
+		t.Errorf("first request expects to authenticate, but issued %d and token is %s", issued, c.Token)
+	}
+
+	valid = "rolled-over"
+	if err := c.UpdateEnvironmentVariables("rails-1", []UpdateEnvironmentVariablesParam{}); err != nil {
+		t.Errorf("expired token expects to be refreshed, but failed: %s", err)
+	}
+	if issued != 2 || c.Token != "token-2" {
+		t.Errorf("401 expects to authenticate again, but issued %d and token is %s", issued, c.Token)
+	}
+}
+
+func TestReauthenticateOnce(t *testing.T) {
+	var calls int
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer s.Close()
+
+	var retrieved int
+	c, err := NewClient(s.URL, WithToken("stale"), WithCredentialProvider(CredentialProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		retrieved++
+		return &Credentials{Token: "also-stale"}, nil
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.DeleteProject("rails-1"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("rejected new token expects unauthorized, but got %v", err)
+	}
+	if calls != 2 || retrieved != 1 {
+		t.Errorf("request expects to be replayed once, but sent %d times and retrieved %d times", calls, retrieved)
+	}
+
+	c.Credentials = CredentialProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		return nil, errors.New("vault is sealed")
+	})
+	if err := c.DeleteProject("rails-1"); err == nil || !strings.Contains(err.Error(), "vault is sealed") {
+		t.Errorf("provider error expects to be returned, but got %v", err)
+	}
+}
+
+func TestPrepareTokenWithoutCredentials(t *testing.T) {
+	var auth []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		io.WriteString(w, `[]`)
+	}))
+	defer s.Close()
+
+	providers := []CredentialProvider{
+		CredentialProviderFunc(func(ctx context.Context) (*Credentials, error) {
+			return &Credentials{}, nil
+		}),
+		StaticCredentials(""),
+	}
+
+	for _, p := range providers {
+		auth = nil
+		c, err := NewClient(s.URL, WithCredentialProvider(p))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.Projects(); err != nil {
+			t.Errorf("request without credentials expects to be sent, but failed: %s", err)
+		}
+		if len(auth) != 1 || auth[0] != "" {
+			t.Errorf("request expects no Authorization header, but got %q", auth)
+		}
+	}
+}
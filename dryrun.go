@@ -0,0 +1,52 @@
+package lolp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// ErrDryRun is returned by mutating request which is printed instead of
+// being sent in dry-run mode
+var ErrDryRun = errors.New("client: dry run")
+
+// WithDryRun prints mutating requests into w instead of sending them, and
+// they return ErrDryRun. Authentication is still sent to obtain token.
+func WithDryRun(w io.Writer) Option {
+	return func(c *Client) error {
+		c.DryRun = w
+		return nil
+	}
+}
+
+// dryRun prints request and returns ErrDryRun when request is mutating in
+// dry-run mode, or returns nil to send it
+func (c *Client) dryRun(req *http.Request) error {
+	if c.DryRun == nil || !contains(mutatingVerbs, req.Method) {
+		return nil
+	}
+	if req.Method == "POST" && strings.HasSuffix(strings.TrimSuffix(req.URL.Path, "/"), authenticatePath) {
+		return nil
+	}
+
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return err
+		}
+		body = b
+	}
+
+	c.logger().Info("dry run", "verb", req.Method, "path", req.URL.Path)
+	fmt.Fprintf(c.DryRun, "%s %s\n", req.Method, req.URL.RequestURI())
+	if len(body) > 0 {
+		fmt.Fprintf(c.DryRun, "%s\n", redactBody(req.URL.Path, body))
+	}
+
+	return ErrDryRun
+}
@@ -0,0 +1,75 @@
+package lolp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	var sent []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "GET":
+			io.WriteString(w, `[]`)
+		default:
+			io.WriteString(w, `"token"`)
+		}
+	}))
+	defer s.Close()
+
+	var out bytes.Buffer
+	c, err := NewClient(s.URL, WithDryRun(&out))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.CreateProject(&ProjectNew{Kind: "php", SubDomain: "foo", DBPassword: "Secret#Gopher123?"}); !errors.Is(err, ErrDryRun) {
+		t.Errorf("create expects dry run, but got %v", err)
+	}
+	if err := c.DeleteProject("foo"); !errors.Is(err, ErrDryRun) {
+		t.Errorf("delete expects dry run, but got %v", err)
+	}
+	if err := c.EnableAutoscaling("foo"); !errors.Is(err, ErrDryRun) {
+		t.Errorf("autoscaling expects dry run, but got %v", err)
+	}
+	if _, err := c.CreateProject(&ProjectNew{}); err == nil || errors.Is(err, ErrDryRun) {
+		t.Errorf("invalid project expects validation error, but got %v", err)
+	}
+
+	if _, err := c.Projects(); err != nil {
+		t.Errorf("GET expects to be sent, but failed: %s", err)
+	}
+	if _, err := c.Authenticate("foo@example.com", "Secret#Gopher123?"); err != nil {
+		t.Errorf("authentication expects to be sent, but failed: %s", err)
+	}
+	if err := c.RevokeToken("token"); !errors.Is(err, ErrDryRun) {
+		t.Errorf("revocation expects dry run, but got %v", err)
+	}
+
+	if expected := []string{"GET /v1/projects", "POST /v1/authenticate"}; strings.Join(sent, ",") != strings.Join(expected, ",") {
+		t.Errorf("sent requests\nexpected: %v\nactual: %v", expected, sent)
+	}
+
+	printed := out.String()
+	for _, s := range []string{
+		"POST /v1/projects\n",
+		`"sub_domain":"foo"`,
+		"DELETE /v1/projects/foo\n",
+		"PUT /v1/projects/foo/autoscaling/enable\n",
+		"DELETE /v1/authenticate\n",
+	} {
+		if !strings.Contains(printed, s) {
+			t.Errorf("dry run output expects %q, but got:\n%s", s, printed)
+		}
+	}
+	if strings.Contains(printed, "Secret#Gopher123?") {
+		t.Errorf("dry run output expects password masked:\n%s", printed)
+	}
+}
@@ -0,0 +1,110 @@
+package lolp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// requestIDHeader for tracing request on API
+	requestIDHeader = "X-Request-Id"
+
+	// OTPHeader is set to "required" on 401 response when account needs
+	// one-time password of two-factor authentication
+	OTPHeader = "X-Lolp-Otp"
+)
+
+var (
+	// ErrUnauthorized for 401 response
+	ErrUnauthorized = errors.New("authentication failed")
+
+	// ErrOTPRequired for 401 response requiring one-time password, which
+	// also matches ErrUnauthorized
+	ErrOTPRequired = errors.New("one-time password required")
+
+	// ErrForbidden for 403 response
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrNotFound for 404 and 410 response
+	ErrNotFound = errors.New("resource not found")
+
+	// ErrConflict for 409 response
+	ErrConflict = errors.New("conflict")
+
+	// ErrRateLimited for 429 response
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrServerError for 5xx response
+	ErrServerError = errors.New("server error")
+)
+
+// APIError struct for non-successful response. It matches the sentinel
+// errors with errors.Is, and unwraps to *AppError when the body has errors.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Method     string
+	Path       string
+	RequestID  string
+	Errors     []string
+	// OTPRequired is whether one-time password is missing or wrong
+	OTPRequired bool
+}
+
+// Error returns error by string
+func (e *APIError) Error() string {
+	switch {
+	case e.StatusCode == http.StatusBadRequest, e.StatusCode == http.StatusUnprocessableEntity:
+		if len(e.Errors) > 0 {
+			return strings.Join(e.Errors, ", ")
+		}
+	case e.StatusCode == http.StatusUnauthorized && e.OTPRequired:
+		return ErrOTPRequired.Error()
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized.Error()
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound.Error()
+	}
+
+	status := e.Status
+	if status == "" {
+		status = fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	if len(e.Errors) > 0 {
+		return fmt.Sprintf("client: %s: %s", status, strings.Join(e.Errors, ", "))
+	}
+
+	return fmt.Sprintf("client: %s", status)
+}
+
+// Is reports whether the status code corresponds to target
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrOTPRequired:
+		return e.StatusCode == http.StatusUnauthorized && e.OTPRequired
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || e.StatusCode == http.StatusGone
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= 500
+	default:
+		return false
+	}
+}
+
+// Unwrap returns errors in response body as *AppError
+func (e *APIError) Unwrap() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return &AppError{Errors: e.Errors}
+}
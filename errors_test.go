@@ -0,0 +1,84 @@
+package lolp
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func statusHandler(t *testing.T) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		code, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/status/"))
+		if err != nil {
+			panic(err.Error())
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-"+strconv.Itoa(code))
+		w.WriteHeader(code)
+		if code != http.StatusInternalServerError {
+			io.WriteString(w, `{"errors":["Something wrong"]}`)
+		}
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(statusHandler(t)))
+	defer s.Close()
+
+	c, err := NewClient(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		code     int
+		sentinel error
+		message  string
+	}{
+		{400, nil, "Something wrong"},
+		{401, ErrUnauthorized, "authentication failed"},
+		{403, ErrForbidden, "client: 403 Forbidden: Something wrong"},
+		{404, ErrNotFound, "resource not found"},
+		{409, ErrConflict, "client: 409 Conflict: Something wrong"},
+		{410, ErrNotFound, "client: 410 Gone: Something wrong"},
+		{422, nil, "Something wrong"},
+		{429, ErrRateLimited, "client: 429 Too Many Requests: Something wrong"},
+		{500, ErrServerError, "client: 500 Internal Server Error"},
+	}
+
+	for _, cc := range cases {
+		_, err := c.HTTP("DELETE", "/status/"+strconv.Itoa(cc.code), nil)
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("%d expects *APIError, but got %#v", cc.code, err)
+		}
+		if apiErr.StatusCode != cc.code || apiErr.Method != "DELETE" || apiErr.Path != "/status/"+strconv.Itoa(cc.code) {
+			t.Errorf("%d has wrong request attributes: %#v", cc.code, apiErr)
+		}
+		if apiErr.RequestID != "req-"+strconv.Itoa(cc.code) {
+			t.Errorf("%d has wrong request id: %s", cc.code, apiErr.RequestID)
+		}
+		if cc.sentinel != nil && !errors.Is(err, cc.sentinel) {
+			t.Errorf("%d expects to be %s", cc.code, cc.sentinel)
+		}
+		if err.Error() != cc.message {
+			t.Errorf("%d error message\nexpected: %s\nactual: %s", cc.code, cc.message, err)
+		}
+
+		var appErr *AppError
+		if hasBody := cc.code != 500; errors.As(err, &appErr) != hasBody {
+			t.Errorf("%d unwrapping to *AppError is wrong: %#v", cc.code, appErr)
+		}
+	}
+
+	_, err = c.HTTP("GET", "/status/404", nil)
+	if errors.Is(err, ErrConflict) {
+		t.Errorf("404 expects not to be %s", ErrConflict)
+	}
+}
@@ -0,0 +1,72 @@
+package lolp
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// IdempotencyKeyHeader identifies mutating request, so that API
+	// processes it once even when the client sends it again
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// ReplayedHeader is set to "true" on response replayed for request of
+	// the same idempotency key
+	ReplayedHeader = "Idempotent-Replayed"
+)
+
+// mutatingVerbs get idempotency key
+var mutatingVerbs = []string{"POST", "PUT", "PATCH", "DELETE"}
+
+// idempotencyKeyContextKey for context value
+type idempotencyKeyContextKey struct{}
+
+// ContextWithIdempotencyKey returns context which sets key on mutating
+// requests instead of generated one, so that the caller can retry the
+// whole operation safely. Empty key disables the header.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// idempotencyKey returns key for request by context, or new one for
+// mutating verb. Authentication never gets the key, since it may be sent
+// with context of another request to obtain token.
+func idempotencyKey(ctx context.Context, verb, spath string) (string, error) {
+	if !contains(mutatingVerbs, verb) || isAuthenticatePath(spath) {
+		return "", nil
+	}
+	if key, ok := ctx.Value(idempotencyKeyContextKey{}).(string); ok {
+		return key, nil
+	}
+	return newIdempotencyKey()
+}
+
+// newIdempotencyKey returns random UUID
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// Replayed returns whether API replayed response of earlier request with
+// the same idempotency key
+func Replayed(res *http.Response) bool {
+	return res != nil && strings.EqualFold(res.Header.Get(ReplayedHeader), "true")
+}
+
+// contains returns whether list has s
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+package lolp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		if len(keys) == 1 && r.Method == "POST" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"id":"1","domain":"foo.lolipop.io"}`)
+	}))
+	defer s.Close()
+
+	c, err := NewClient(s.URL, WithRetry(&RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		verb    string
+		ctx     context.Context
+		calls   int
+		wantErr bool
+		key     string
+	}{
+		{"POST", context.Background(), 2, false, "generated"},
+		{"POST", ContextWithIdempotencyKey(context.Background(), "my-key"), 2, false, "my-key"},
+		{"POST", ContextWithIdempotencyKey(context.Background(), ""), 1, true, ""},
+		{"DELETE", context.Background(), 1, false, "generated"},
+		{"GET", context.Background(), 1, false, ""},
+	}
+
+	for _, cc := range cases {
+		keys = nil
+		_, err := c.HTTPContext(cc.ctx, cc.verb, "/v1/projects", &RequestOptions{Body: strings.NewReader(`{}`)})
+		if cc.wantErr != (err != nil) {
+			t.Errorf("%s expects error %t, but got %v", cc.verb, cc.wantErr, err)
+		}
+		if len(keys) != cc.calls {
+			t.Fatalf("%s expects %d calls, but got %d", cc.verb, cc.calls, len(keys))
+		}
+		for _, k := range keys {
+			if k != keys[0] {
+				t.Errorf("%s expects the same key across retries, but got %v", cc.verb, keys)
+			}
+		}
+		switch cc.key {
+		case "generated":
+			if len(keys[0]) != 36 {
+				t.Errorf("%s expects generated key, but got %q", cc.verb, keys[0])
+			}
+		default:
+			if keys[0] != cc.key {
+				t.Errorf("%s expects key %q, but got %q", cc.verb, cc.key, keys[0])
+			}
+		}
+	}
+}
+
+func TestCreateProjectReplayed(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(IdempotencyKeyHeader) == "done" {
+			w.Header().Set(ReplayedHeader, "true")
+		}
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"id":"1","domain":"foo.lolipop.io"}`)
+	}))
+	defer s.Close()
+
+	c, err := NewClient(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for key, expected := range map[string]bool{"new": false, "done": true} {
+		ctx := ContextWithIdempotencyKey(context.Background(), key)
+		r, err := c.CreateProjectContext(ctx, &ProjectNew{Kind: "php", DBPassword: "secret"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Replayed != expected {
+			t.Errorf("key %s expects replayed %t, but got %t", key, expected, r.Replayed)
+		}
+	}
+}
+
+func TestIdempotencyKeyReauthenticate(t *testing.T) {
+	var mu sync.Mutex
+	var sent []string
+	replays := map[string]string{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		key := r.Header.Get(IdempotencyKeyHeader)
+		sent = append(sent, r.Method+" "+r.URL.Path+" "+key)
+
+		// replays are stored by key alone like real API
+		if body, ok := replays[key]; ok && key != "" {
+			w.Header().Set(ReplayedHeader, "true")
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, body)
+			return
+		}
+
+		body := `{"id":"1","domain":"foo.lolipop.io"}`
+		if r.URL.Path == "/v1/authenticate" {
+			body = `"token"`
+		} else if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if key != "" {
+			replays[key] = body
+		}
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, body)
+	}))
+	defer s.Close()
+
+	c, err := NewClient(s.URL, WithCredentialProvider(PasswordCredentials("foo@example.com", "Secret#Gopher123?")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := ContextWithIdempotencyKey(context.Background(), "K1")
+	r, err := c.CreateProjectContext(ctx, &ProjectNew{Kind: "php", DBPassword: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Replayed || r.Domain != "foo.lolipop.io" {
+		t.Errorf("create expects not to replay authentication: %#v", r)
+	}
+
+	expected := []string{"POST /v1/authenticate ", "POST /v1/projects K1"}
+	if strings.Join(sent, ",") != strings.Join(expected, ",") {
+		t.Errorf("sent requests\nexpected: %q\nactual: %q", expected, sent)
+	}
+}
@@ -0,0 +1,65 @@
+package lolp
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+)
+
+// Logger interface for client logging. Arguments after msg are key-value
+// pairs like log/slog, so *slog.Logger satisfies this as it is.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// nopLogger discards all logs
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// logger returns logger for client masking secrets, silent by default
+func (c *Client) logger() Logger {
+	if c.Logger == nil {
+		return nopLogger{}
+	}
+	return &redactLogger{l: c.Logger}
+}
+
+// stdLogger struct
+type stdLogger struct {
+	l *log.Logger
+}
+
+// NewStdLogger returns Logger writing lines as "[LEVEL] msg: key=value"
+// through l, which works with level filters such as hashicorp/logutils
+func NewStdLogger(l *log.Logger) Logger {
+	return &stdLogger{l: l}
+}
+
+func (s *stdLogger) Debug(msg string, kv ...interface{}) { s.output("DEBUG", msg, kv) }
+func (s *stdLogger) Info(msg string, kv ...interface{})  { s.output("INFO", msg, kv) }
+func (s *stdLogger) Warn(msg string, kv ...interface{})  { s.output("WARN", msg, kv) }
+func (s *stdLogger) Error(msg string, kv ...interface{}) { s.output("ERROR", msg, kv) }
+
+// output writes a line with level
+func (s *stdLogger) output(level, msg string, kv []interface{}) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "[%s] %s", level, msg)
+	for i := 0; i < len(kv); i += 2 {
+		if i == 0 {
+			buf.WriteString(":")
+		}
+		if i+1 < len(kv) {
+			fmt.Fprintf(&buf, " %v=%v", kv[i], kv[i+1])
+		} else {
+			fmt.Fprintf(&buf, " %v", kv[i])
+		}
+	}
+	s.l.Output(3, buf.String())
+}
@@ -0,0 +1,16 @@
+//go:build go1.21
+// +build go1.21
+
+package lolp
+
+import (
+	"log/slog"
+)
+
+// NewSlogLogger returns Logger backed by l, or slog.Default() when l is nil
+func NewSlogLogger(l *slog.Logger) Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return l
+}
@@ -0,0 +1,68 @@
+package lolp
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type recordLogger struct {
+	lines []string
+}
+
+func (r *recordLogger) record(level, msg string, kv []interface{}) {
+	r.lines = append(r.lines, fmt.Sprintf("%s %s %v", level, msg, kv))
+}
+
+func (r *recordLogger) Debug(msg string, kv ...interface{}) { r.record("DEBUG", msg, kv) }
+func (r *recordLogger) Info(msg string, kv ...interface{})  { r.record("INFO", msg, kv) }
+func (r *recordLogger) Warn(msg string, kv ...interface{})  { r.record("WARN", msg, kv) }
+func (r *recordLogger) Error(msg string, kv ...interface{}) { r.record("ERROR", msg, kv) }
+
+func TestStdLogger(t *testing.T) {
+	o := new(bytes.Buffer)
+	l := NewStdLogger(log.New(o, "", 0))
+
+	l.Info("response", "verb", "GET", "status", 200)
+	l.Debug("no fields")
+
+	expected := "[INFO] response: verb=GET status=200\n[DEBUG] no fields\n"
+	if o.String() != expected {
+		t.Errorf("log output\nexpected: %s\nactual: %s", expected, o)
+	}
+}
+
+func TestClientLogger(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+
+	c, err := NewClient(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.logger().(nopLogger); !ok {
+		t.Errorf("default logger expects to be silent")
+	}
+
+	l := &recordLogger{}
+	c.Logger = l
+	if _, err := c.HTTP("GET", "/v1/projects", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, line := range l.lines {
+		if strings.HasPrefix(line, "INFO response [verb GET path /v1/projects status 200 duration") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("response log not found in %q", l.lines)
+	}
+}
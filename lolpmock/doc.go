@@ -0,0 +1,6 @@
+// Package lolpmock provides APIMock, which implements lolp.API and the
+// service interfaces, for unit tests of code using lolp.Client.
+//
+// APIMock is generated by moq, so run go generate in the lolp package
+// after changing the interfaces.
+package lolpmock
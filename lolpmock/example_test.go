@@ -0,0 +1,36 @@
+package lolpmock_test
+
+import (
+	"errors"
+	"fmt"
+
+	lolp "github.com/pepabo/golipop"
+	"github.com/pepabo/golipop/lolpmock"
+)
+
+// recreate deletes project and creates it again
+func recreate(s lolp.ProjectService, name, kind string) error {
+	if err := s.DeleteProject(name); err != nil && !errors.Is(err, lolp.ErrNotFound) {
+		return err
+	}
+	_, err := s.CreateProject(&lolp.ProjectNew{Kind: kind, SubDomain: name})
+	return err
+}
+
+func ExampleAPIMock() {
+	m := &lolpmock.APIMock{
+		DeleteProjectFunc: func(name string) error {
+			return lolp.ErrNotFound
+		},
+		CreateProjectFunc: func(p *lolp.ProjectNew) (*lolp.ProjectCreateResponse, error) {
+			return &lolp.ProjectCreateResponse{Domain: p.SubDomain + ".lolipop.io"}, nil
+		},
+	}
+
+	if err := recreate(m, "foo", "php"); err != nil {
+		panic(err)
+	}
+
+	fmt.Println(len(m.DeleteProjectCalls()), m.CreateProjectCalls()[0].P.SubDomain)
+	// Output: 1 foo
+}
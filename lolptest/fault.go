@@ -0,0 +1,163 @@
+package lolptest
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Fault struct describes misbehavior injected into matching requests
+type Fault struct {
+	// Method matches request method, or any method when empty
+	Method string
+	// Path matches request path by path.Match pattern, or any path when empty
+	Path string
+	// Times limits the number of affected requests, or every request when 0
+	Times int
+
+	// Latency delays request, and is canceled by request context
+	Latency time.Duration
+	// Reset fails request with connection reset by peer
+	Reset bool
+	// Status responds with status code without sending request
+	Status int
+	// RetryAfter sets Retry-After header with Status
+	RetryAfter time.Duration
+	// Truncate cuts response body in half to make broken JSON
+	Truncate bool
+
+	hits int
+}
+
+// match returns whether fault applies to request
+func (f *Fault) match(req *http.Request) bool {
+	if f.Times > 0 && f.hits >= f.Times {
+		return false
+	}
+	if f.Method != "" && f.Method != req.Method {
+		return false
+	}
+	if f.Path != "" {
+		if ok, err := path.Match(f.Path, req.URL.Path); err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// FaultTransport is http.RoundTripper injecting faults for resilience
+// testing. The first matching fault applies to each request.
+type FaultTransport struct {
+	// Next sends requests, http.DefaultTransport when nil
+	Next   http.RoundTripper
+	Faults []*Fault
+
+	mu sync.Mutex
+}
+
+// NewFaultTransport returns transport injecting faults into next
+func NewFaultTransport(next http.RoundTripper, faults ...*Fault) *FaultTransport {
+	return &FaultTransport{Next: next, Faults: faults}
+}
+
+// Hits returns the number of requests affected by fault
+func (t *FaultTransport) Hits(f *Fault) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return f.hits
+}
+
+// fault returns matching fault and counts the hit
+func (t *FaultTransport) fault(req *http.Request) *Fault {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, f := range t.Faults {
+		if f.match(req) {
+			f.hits++
+			return f
+		}
+	}
+	return nil
+}
+
+// RoundTrip sends request with fault
+func (t *FaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	f := t.fault(req)
+	if f == nil {
+		return next.RoundTrip(req)
+	}
+
+	if f.Latency > 0 {
+		timer := time.NewTimer(f.Latency)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	if f.Reset {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	}
+
+	if f.Status > 0 {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return faultResponse(req, f), nil
+	}
+
+	res, err := next.RoundTrip(req)
+	if err != nil || !f.Truncate {
+		return res, err
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	body = body[:len(body)/2]
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+	res.ContentLength = int64(len(body))
+	res.Header.Del("Content-Length")
+
+	return res, nil
+}
+
+// faultResponse returns response with status of fault
+func faultResponse(req *http.Request, f *Fault) *http.Response {
+	body := fmt.Sprintf(`{"errors":["%s"]}`, http.StatusText(f.Status))
+	h := http.Header{"Content-Type": []string{"application/json"}}
+	if f.RetryAfter > 0 {
+		h.Set("Retry-After", strconv.Itoa(int((f.RetryAfter+time.Second-1)/time.Second)))
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.Status, http.StatusText(f.Status)),
+		StatusCode:    f.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        h,
+		Body:          ioutil.NopCloser(bytes.NewBufferString(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,74 @@
+package lolptest_test
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	lolp "github.com/pepabo/golipop"
+	"github.com/pepabo/golipop/lolptest"
+)
+
+func TestFaultTransport(t *testing.T) {
+	s := lolptest.NewServer()
+	defer s.Close()
+	s.AddProject(lolp.Project{SubDomain: "rails-1", Kind: "rails"})
+
+	burst := &lolptest.Fault{Path: "/v1/projects", Status: 503, Times: 2}
+	limited := &lolptest.Fault{Method: "GET", Path: "/v1/projects/*", Status: 429, RetryAfter: time.Second, Times: 1}
+	ft := lolptest.NewFaultTransport(nil, burst, limited)
+
+	c, err := s.NewClient(
+		lolp.WithTransport(ft),
+		lolp.WithRetry(&lolp.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Second}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Projects(); err != nil {
+		t.Errorf("retry expects to survive 5xx burst, but failed: %s", err)
+	}
+	if ft.Hits(burst) != 2 {
+		t.Errorf("burst expects 2 hits, but got %d", ft.Hits(burst))
+	}
+
+	start := time.Now()
+	if _, err := c.Project("rails-1"); err != nil {
+		t.Errorf("retry expects to survive rate limit, but failed: %s", err)
+	}
+	if time.Since(start) < time.Second {
+		t.Errorf("retry expects to honour Retry-After")
+	}
+}
+
+func TestFaultTransportErrors(t *testing.T) {
+	s := lolptest.NewServer()
+	defer s.Close()
+	s.AddProject(lolp.Project{SubDomain: "rails-1", Kind: "rails"})
+
+	ft := lolptest.NewFaultTransport(nil,
+		&lolptest.Fault{Path: "/v1/projects/rails-1", Truncate: true},
+		&lolptest.Fault{Method: "DELETE", Reset: true},
+		&lolptest.Fault{Path: "/v1/projects", Latency: time.Second},
+	)
+	c, err := s.NewClient(lolp.WithTransport(ft))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Project("rails-1"); err == nil {
+		t.Errorf("truncated JSON expects decode error")
+	}
+	if err := c.DeletePublicKey("laptop"); !errors.Is(err, syscall.ECONNRESET) {
+		t.Errorf("reset expects connection reset, but got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.ProjectsContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("latency expects deadline exceeded, but got %v", err)
+	}
+}
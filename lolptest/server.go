@@ -0,0 +1,495 @@
+// Package lolptest provides a fake Lolipop! Managed Cloud API server for
+// testing code built on lolp.Client without a live account.
+//
+//	s := lolptest.NewServer()
+//	defer s.Close()
+//	s.AddUser("foo@example.com", "password")
+//	c, err := s.NewClient()
+package lolptest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	lolp "github.com/pepabo/golipop"
+)
+
+// domainSuffix for project domains
+const domainSuffix = ".lolipop.io"
+
+// kinds of project
+var kinds = []string{"wordpress", "php", "rails", "node"}
+
+// Server struct is stateful fake API server
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	users    map[string]string
+	otps     map[string]string
+	tokens   map[string]string
+	projects map[string]*lolp.Project
+	pubkeys  map[string]*lolp.PublicKey
+	envs     map[string]map[string]string
+	replays  map[string]*replay
+}
+
+// NewServer starts and returns fake API server, and the caller should
+// call Close when finished
+func NewServer() *Server {
+	s := &Server{
+		users:    make(map[string]string),
+		otps:     make(map[string]string),
+		tokens:   make(map[string]string),
+		projects: make(map[string]*lolp.Project),
+		pubkeys:  make(map[string]*lolp.PublicKey),
+		envs:     make(map[string]map[string]string),
+		replays:  make(map[string]*replay),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// NewClient returns client for server, authorized by a token of new user
+// unless options set another token
+func (s *Server) NewClient(opts ...lolp.Option) (*lolp.Client, error) {
+	token := s.IssueToken("lolptest@example.com")
+	return lolp.NewClient(s.URL, append([]lolp.Option{lolp.WithToken(token)}, opts...)...)
+}
+
+// AddUser registers user for authentication
+func (s *Server) AddUser(username, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[username] = password
+}
+
+// EnableOTP requires one-time password code of user for authentication
+func (s *Server) EnableOTP(username, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.otps[username] = code
+}
+
+// IssueToken returns valid token for username without authentication
+func (s *Server) IssueToken(username string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.issueToken(username)
+}
+
+// issueToken returns new token
+func (s *Server) issueToken(username string) string {
+	t := randomHex(20)
+	s.tokens[t] = username
+	return t
+}
+
+// RevokeToken invalidates token to simulate expiry
+func (s *Server) RevokeToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, token)
+}
+
+// AddProject stores project, and fills ID, domain and timestamps if empty
+func (s *Server) AddProject(p lolp.Project) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addProject(&p)
+}
+
+// addProject stores project with defaults
+func (s *Server) addProject(p *lolp.Project) {
+	if p.ID == "" {
+		p.ID = randomUUID()
+	}
+	if p.SubDomain == "" {
+		p.SubDomain = "project-" + randomHex(4)
+	}
+	if p.Domain == "" {
+		p.Domain = p.SubDomain + domainSuffix
+	}
+	if p.Name == "" {
+		p.Name = p.Domain
+	}
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = now
+	}
+	if p.UpdatedAt.IsZero() {
+		p.UpdatedAt = now
+	}
+	s.projects[p.SubDomain] = p
+	if _, ok := s.envs[p.SubDomain]; !ok {
+		s.envs[p.SubDomain] = make(map[string]string)
+	}
+}
+
+// Projects returns copy of stored projects ordered by sub-domain
+func (s *Server) Projects() []lolp.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.projectList()
+}
+
+// projectList returns projects ordered by sub-domain
+func (s *Server) projectList() []lolp.Project {
+	ps := make([]lolp.Project, 0, len(s.projects))
+	for _, p := range s.projects {
+		ps = append(ps, *p)
+	}
+	sort.Slice(ps, func(i, j int) bool { return ps[i].SubDomain < ps[j].SubDomain })
+	return ps
+}
+
+// PublicKeys returns copy of stored public keys ordered by name
+func (s *Server) PublicKeys() []lolp.PublicKey {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ks := make([]lolp.PublicKey, 0, len(s.pubkeys))
+	for _, k := range s.pubkeys {
+		ks = append(ks, *k)
+	}
+	sort.Slice(ks, func(i, j int) bool { return ks[i].Name < ks[j].Name })
+	return ks
+}
+
+// EnvironmentVariables returns copy of environment variables of project
+func (s *Server) EnvironmentVariables(name string) map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	vars := make(map[string]string)
+	for k, v := range s.envs[name] {
+		vars[k] = v
+	}
+	return vars
+}
+
+// serveHTTP routes requests
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "v1" {
+		writeErrors(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	// authorization is checked before idempotency lookup, not to store 401
+	// which client retries with the same key after authentication
+	login := parts[1] == "authenticate" && len(parts) == 2 && r.Method == "POST"
+	if !login && !s.authorized(r) {
+		writeErrors(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if key := r.Header.Get(lolp.IdempotencyKeyHeader); key != "" && r.Method == "POST" {
+		s.serveIdempotent(w, r, parts, key)
+		return
+	}
+
+	s.route(w, r, parts)
+}
+
+// route routes requests
+func (s *Server) route(w http.ResponseWriter, r *http.Request, parts []string) {
+	if parts[1] == "authenticate" && len(parts) == 2 && r.Method == "POST" {
+		s.authenticate(w, r)
+		return
+	}
+
+	switch parts[1] {
+	case "authenticate":
+		s.routeAuthenticate(w, r, parts[2:])
+	case "projects":
+		s.routeProjects(w, r, parts[2:])
+	case "pubkeys":
+		s.routePubkeys(w, r, parts[2:])
+	default:
+		writeErrors(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// serveIdempotent serves POST request once for idempotency key, and
+// replays the response for the same key. Like real API, replays are stored
+// by key alone, so the key reused for another path is rejected.
+func (s *Server) serveIdempotent(w http.ResponseWriter, r *http.Request, parts []string, key string) {
+	rp, ok := s.replays[key]
+	switch {
+	case !ok:
+		rec := httptest.NewRecorder()
+		s.route(rec, r, parts)
+		if rec.Code < 500 {
+			s.replays[key] = &replay{path: r.URL.Path, rec: rec}
+		}
+		copyResponse(w, rec)
+	case rp.path != r.URL.Path:
+		writeErrors(w, http.StatusUnprocessableEntity, "Idempotency key is already used for another request")
+	default:
+		w.Header().Set(lolp.ReplayedHeader, "true")
+		copyResponse(w, rp.rec)
+	}
+}
+
+// replay struct for response stored by idempotency key
+type replay struct {
+	path string
+	rec  *httptest.ResponseRecorder
+}
+
+// copyResponse writes recorded response
+func copyResponse(w http.ResponseWriter, rec *httptest.ResponseRecorder) {
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(rec.Code)
+	w.Write(rec.Body.Bytes())
+}
+
+// authorized returns whether request has valid bearer token
+func (s *Server) authorized(r *http.Request) bool {
+	a := r.Header.Get("Authorization")
+	if !strings.HasPrefix(a, "Bearer ") {
+		return false
+	}
+	_, ok := s.tokens[strings.TrimPrefix(a, "Bearer ")]
+	return ok
+}
+
+// authenticate issues token by username and password
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) {
+	var l struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		OTP      string `json:"otp"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&l); err != nil {
+		writeErrors(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	p, ok := s.users[l.Username]
+	if !ok || p != l.Password {
+		writeErrors(w, http.StatusUnauthorized, "Invalid username or password")
+		return
+	}
+
+	if code, ok := s.otps[l.Username]; ok && l.OTP != code {
+		w.Header().Set(lolp.OTPHeader, "required")
+		writeErrors(w, http.StatusUnauthorized, "One-time password required")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.issueToken(l.Username))
+}
+
+// routeAuthenticate revokes token of request
+func (s *Server) routeAuthenticate(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) > 0 || r.Method != "DELETE" {
+		writeErrors(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	delete(s.tokens, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// routeProjects routes requests under /v1/projects
+func (s *Server) routeProjects(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == "GET":
+		writeJSON(w, http.StatusOK, s.projectList())
+	case len(parts) == 0 && r.Method == "POST":
+		s.createProject(w, r)
+	case len(parts) == 0:
+		writeErrors(w, http.StatusMethodNotAllowed, "Method not allowed")
+	default:
+		p, ok := s.projects[parts[0]]
+		if !ok {
+			writeErrors(w, http.StatusNotFound, "Not found")
+			return
+		}
+		s.routeProject(w, r, p, parts[1:])
+	}
+}
+
+// routeProject routes requests under /v1/projects/<sub-domain>
+func (s *Server) routeProject(w http.ResponseWriter, r *http.Request, p *lolp.Project, parts []string) {
+	route := r.Method + " " + strings.Join(parts, "/")
+	switch route {
+	case "GET ":
+		writeJSON(w, http.StatusOK, p)
+	case "DELETE ":
+		delete(s.projects, p.SubDomain)
+		delete(s.envs, p.SubDomain)
+		w.WriteHeader(http.StatusNoContent)
+	case "PUT autoscaling/enable", "PUT autoscaling/disable":
+		p.Autoscalable = parts[1] == "enable"
+		p.UpdatedAt = time.Now().UTC().Truncate(time.Millisecond)
+		w.WriteHeader(http.StatusCreated)
+	case "GET environment-variables":
+		writeJSON(w, http.StatusOK, s.envs[p.SubDomain])
+	case "PUT environment-variables":
+		s.updateEnvironmentVariables(w, r, p)
+	default:
+		writeErrors(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// createProject creates project by lolp.ProjectNew
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	var n lolp.ProjectNew
+	if err := json.NewDecoder(r.Body).Decode(&n); err != nil {
+		writeErrors(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	var errs []string
+	if !contains(kinds, n.Kind) {
+		errs = append(errs, fmt.Sprintf("Kind must be one of %s", strings.Join(kinds, ", ")))
+	}
+	if _, ok := s.projects[n.SubDomain]; ok && n.SubDomain != "" {
+		errs = append(errs, "Sub domain has already been taken")
+	}
+	if n.Kind == "wordpress" {
+		for _, k := range []string{"username", "password", "email"} {
+			if v, _ := n.Payload[k].(string); v == "" {
+				errs = append(errs, fmt.Sprintf("Payload %s is required", k))
+			}
+		}
+	} else if n.Kind != "" && n.DBPassword == "" {
+		errs = append(errs, "DB password is required")
+	}
+	if len(errs) > 0 {
+		writeErrors(w, http.StatusUnprocessableEntity, errs...)
+		return
+	}
+
+	p := &lolp.Project{Kind: n.Kind, SubDomain: n.SubDomain}
+	for _, d := range n.CustomDomains {
+		p.CustomDomains = append(p.CustomDomains, lolp.CustomDomain{Name: d})
+	}
+	s.addProject(p)
+
+	writeJSON(w, http.StatusCreated, &lolp.ProjectCreateResponse{ID: p.ID, Domain: p.Domain})
+}
+
+// updateEnvironmentVariables applies create, update and delete of variables
+func (s *Server) updateEnvironmentVariables(w http.ResponseWriter, r *http.Request, p *lolp.Project) {
+	var params []lolp.UpdateEnvironmentVariablesParam
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		writeErrors(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	vars := s.envs[p.SubDomain]
+	next := make(map[string]string)
+	for k, v := range vars {
+		next[k] = v
+	}
+
+	var errs []string
+	for _, param := range params {
+		k := param.Variable.Key
+		_, exists := next[k]
+		switch {
+		case k == "":
+			errs = append(errs, "Key is required")
+		case param.Method == "create" && exists:
+			errs = append(errs, fmt.Sprintf("%s already exists", k))
+		case (param.Method == "update" || param.Method == "delete") && !exists:
+			errs = append(errs, fmt.Sprintf("%s does not exist", k))
+		case param.Method == "create", param.Method == "update":
+			next[k] = param.Variable.Value
+		case param.Method == "delete":
+			delete(next, k)
+		default:
+			errs = append(errs, fmt.Sprintf("Unknown method: %s", param.Method))
+		}
+	}
+	if len(errs) > 0 {
+		writeErrors(w, http.StatusUnprocessableEntity, errs...)
+		return
+	}
+
+	s.envs[p.SubDomain] = next
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// routePubkeys routes requests under /v1/pubkeys
+func (s *Server) routePubkeys(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == "POST":
+		var k lolp.PublicKey
+		if err := json.NewDecoder(r.Body).Decode(&k); err != nil {
+			writeErrors(w, http.StatusBadRequest, "Invalid JSON")
+			return
+		}
+		if _, ok := s.pubkeys[k.Name]; ok {
+			writeErrors(w, http.StatusUnprocessableEntity, "Name has already been taken")
+			return
+		}
+		if k.Name == "" || k.Key == "" {
+			writeErrors(w, http.StatusUnprocessableEntity, "Name and key are required")
+			return
+		}
+		s.pubkeys[k.Name] = &k
+		writeJSON(w, http.StatusOK, &k)
+	case len(parts) == 1 && r.Method == "DELETE":
+		if _, ok := s.pubkeys[parts[0]]; !ok {
+			writeErrors(w, http.StatusNotFound, "Not found")
+			return
+		}
+		delete(s.pubkeys, parts[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeErrors(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// writeJSON writes response as JSON
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeErrors writes error response in lolp.AppError format
+func writeErrors(w http.ResponseWriter, code int, errs ...string) {
+	writeJSON(w, code, &lolp.AppError{Errors: errs})
+}
+
+// contains returns whether s is in list
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// randomHex returns random hex string of n bytes
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// randomUUID returns random UUID version 4
+func randomUUID() string {
+	h := randomHex(16)
+	return fmt.Sprintf("%s-%s-4%s-a%s-%s", h[0:8], h[8:12], h[13:16], h[17:20], h[20:32])
+}
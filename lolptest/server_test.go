@@ -0,0 +1,227 @@
+package lolptest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	lolp "github.com/pepabo/golipop"
+	"github.com/pepabo/golipop/lolptest"
+)
+
+func TestAuthenticate(t *testing.T) {
+	s := lolptest.NewServer()
+	defer s.Close()
+	s.AddUser("foo@example.com", "Secret#Gopher123?")
+
+	c, err := lolp.NewClient(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Projects(); !errors.Is(err, lolp.ErrUnauthorized) {
+		t.Errorf("request without token expects unauthorized, but got %v", err)
+	}
+	if _, err := c.Authenticate("foo@example.com", "wrong"); !errors.Is(err, lolp.ErrUnauthorized) {
+		t.Errorf("wrong password expects unauthorized, but got %v", err)
+	}
+	if _, err := c.Authenticate("foo@example.com", "Secret#Gopher123?"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Projects(); err != nil {
+		t.Errorf("request with token expects to succeed, but failed: %s", err)
+	}
+
+	s.RevokeToken(c.Token)
+	if _, err := c.Projects(); !errors.Is(err, lolp.ErrUnauthorized) {
+		t.Errorf("revoked token expects unauthorized, but got %v", err)
+	}
+
+	s.EnableOTP("foo@example.com", "123456")
+	if _, err := c.Authenticate("foo@example.com", "Secret#Gopher123?"); !errors.Is(err, lolp.ErrOTPRequired) {
+		t.Errorf("authentication without otp expects otp required, but got %v", err)
+	}
+	if _, err := c.AuthenticateWithOTP("foo@example.com", "Secret#Gopher123?", "123456"); err != nil {
+		t.Errorf("authentication with otp expects to succeed, but failed: %s", err)
+	}
+
+	token := c.Token
+	if err := c.Logout(); err != nil {
+		t.Fatal(err)
+	}
+	c.Token = token
+	if _, err := c.Projects(); !errors.Is(err, lolp.ErrUnauthorized) {
+		t.Errorf("token after logout expects unauthorized, but got %v", err)
+	}
+}
+
+func TestProjects(t *testing.T) {
+	s := lolptest.NewServer()
+	defer s.Close()
+	s.AddProject(lolp.Project{SubDomain: "rails-1", Kind: "rails"})
+
+	c, err := s.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := c.CreateProject(&lolp.ProjectNew{Kind: "php", SubDomain: "php-1", DBPassword: "Secret#Gopher123?"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Domain != "php-1.lolipop.io" || r.ID == "" {
+		t.Errorf("create response is wrong: %#v", r)
+	}
+
+	_, err = c.CreateProject(&lolp.ProjectNew{Kind: "php", SubDomain: "php-1"})
+	var appErr *lolp.AppError
+	if !errors.As(err, &appErr) || len(appErr.Errors) != 2 {
+		t.Errorf("invalid project expects validation errors, but got %v", err)
+	}
+
+	ps, err := c.Projects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*ps) != 2 || (*ps)[0].SubDomain != "php-1" || (*ps)[1].SubDomain != "rails-1" {
+		t.Errorf("projects are wrong: %#v", *ps)
+	}
+
+	if err := c.EnableAutoscaling("rails-1"); err != nil {
+		t.Fatal(err)
+	}
+	p, err := c.Project("rails-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Autoscalable {
+		t.Errorf("autoscaling expects to be enabled")
+	}
+
+	if err := c.DeleteProject("rails-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteProject("rails-1"); !errors.Is(err, lolp.ErrNotFound) {
+		t.Errorf("deleted project expects not found, but got %v", err)
+	}
+	if len(s.Projects()) != 1 {
+		t.Errorf("server expects 1 project, but got %d", len(s.Projects()))
+	}
+}
+
+func TestEnvironmentVariables(t *testing.T) {
+	s := lolptest.NewServer()
+	defer s.Close()
+	s.AddProject(lolp.Project{SubDomain: "rails-1", Kind: "rails"})
+
+	c, err := s.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	param := lolp.UpdateEnvironmentVariablesParam{Method: "create"}
+	param.Variable.Key = "API_KEY"
+	param.Variable.Value = "s3cr3t"
+	if err := c.UpdateEnvironmentVariables("rails-1", []lolp.UpdateEnvironmentVariablesParam{param}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UpdateEnvironmentVariables("rails-1", []lolp.UpdateEnvironmentVariablesParam{param}); err == nil {
+		t.Errorf("creating existing variable expects error")
+	}
+
+	res, err := c.GetEnvironmentVariables("rails-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vars map[string]string
+	if err := json.Unmarshal([]byte(res), &vars); err != nil {
+		t.Fatal(err)
+	}
+	if vars["API_KEY"] != "s3cr3t" || s.EnvironmentVariables("rails-1")["API_KEY"] != "s3cr3t" {
+		t.Errorf("environment variables are wrong: %s", res)
+	}
+}
+
+func TestPublicKeys(t *testing.T) {
+	s := lolptest.NewServer()
+	defer s.Close()
+
+	c, err := s.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.AddPublicKey(&lolp.PublicKey{Name: "laptop", Key: "ssh-ed25519 AAAA"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AddPublicKey(&lolp.PublicKey{Name: "laptop", Key: "ssh-ed25519 AAAA"}); err == nil {
+		t.Errorf("duplicated public key expects error")
+	}
+	if len(s.PublicKeys()) != 1 {
+		t.Errorf("server expects 1 public key, but got %d", len(s.PublicKeys()))
+	}
+	if err := c.DeletePublicKey("laptop"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeletePublicKey("laptop"); !errors.Is(err, lolp.ErrNotFound) {
+		t.Errorf("deleted public key expects not found, but got %v", err)
+	}
+}
+
+func TestIdempotency(t *testing.T) {
+	s := lolptest.NewServer()
+	defer s.Close()
+
+	c, err := s.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := lolp.ContextWithIdempotencyKey(context.Background(), "create-foo")
+	n := &lolp.ProjectNew{Kind: "php", SubDomain: "foo", DBPassword: "Secret#Gopher123?"}
+
+	first, err := c.CreateProjectContext(ctx, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := c.CreateProjectContext(ctx, n)
+	if err != nil {
+		t.Fatalf("retry with the same key expects replayed response, but failed: %s", err)
+	}
+
+	if first.Replayed || !second.Replayed {
+		t.Errorf("only second response expects to be replayed: %t, %t", first.Replayed, second.Replayed)
+	}
+	if first.ID != second.ID || len(s.Projects()) != 1 {
+		t.Errorf("retry expects not to create duplicate project: %d projects", len(s.Projects()))
+	}
+
+	if _, err := c.CreateProject(n); err == nil {
+		t.Errorf("another key expects to be processed again, and fail by taken sub domain")
+	}
+
+	s.AddUser("foo@example.com", "Secret#Gopher123?")
+	c, err = lolp.NewClient(s.URL, lolp.WithCredentialProvider(lolp.PasswordCredentials("foo@example.com", "Secret#Gopher123?")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = lolp.ContextWithIdempotencyKey(context.Background(), "create-bar")
+	r, err := c.CreateProjectContext(ctx, &lolp.ProjectNew{Kind: "php", SubDomain: "bar", DBPassword: "Secret#Gopher123?"})
+	if err != nil {
+		t.Fatalf("create after authentication expects to succeed, but failed: %s", err)
+	}
+	if r.Replayed || r.Domain != "bar.lolipop.io" {
+		t.Errorf("create expects not to replay authentication: %#v", r)
+	}
+
+	s.RevokeToken(c.Token)
+	ctx = lolp.ContextWithIdempotencyKey(context.Background(), "create-baz")
+	r, err = c.CreateProjectContext(ctx, &lolp.ProjectNew{Kind: "php", SubDomain: "baz", DBPassword: "Secret#Gopher123?"})
+	if err != nil {
+		t.Fatalf("create after re-authentication expects to succeed, but failed: %s", err)
+	}
+	if r.Replayed || r.Domain != "baz.lolipop.io" {
+		t.Errorf("create expects not to replay unauthorized response: %#v", r)
+	}
+}
@@ -0,0 +1,64 @@
+package lolp
+
+import (
+	"net/http"
+)
+
+// RoundTripperFunc adapts function to http.RoundTripper
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps round trip of every API call, including each retry
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// Use appends middlewares to client, where the first one is outermost
+func (c *Client) Use(mw ...Middleware) {
+	c.Middlewares = append(c.Middlewares, mw...)
+}
+
+// WithMiddleware appends middlewares to client
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) error {
+		c.Use(mw...)
+		return nil
+	}
+}
+
+// RequestMutator returns middleware that changes copy of request before
+// sending, such as adding headers. Error aborts the request.
+func RequestMutator(f func(*http.Request) error) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			r := req.Clone(req.Context())
+			if err := f(r); err != nil {
+				return nil, err
+			}
+			return next.RoundTrip(r)
+		})
+	}
+}
+
+// ResponseObserver returns middleware that is called with response or
+// error after each round trip, such as for metrics or audit logging
+func ResponseObserver(f func(*http.Request, *http.Response, error)) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			res, err := next.RoundTrip(req)
+			f(req, res, err)
+			return res, err
+		})
+	}
+}
+
+// roundTrip sends request through middlewares to HTTP client
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	var rt http.RoundTripper = RoundTripperFunc(c.HTTPClient.Do)
+	for i := len(c.Middlewares) - 1; i >= 0; i-- {
+		rt = c.Middlewares[i](rt)
+	}
+	return rt.RoundTrip(req)
+}
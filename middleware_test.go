@@ -0,0 +1,75 @@
+package lolp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMiddleware(t *testing.T) {
+	var calls int
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("X-Trace-Id") != "trace-1" {
+			t.Errorf("X-Trace-Id header is wrong: %s", r.Header.Get("X-Trace-Id"))
+		}
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+
+	var order []string
+	var statuses []int
+	c, err := NewClient(s.URL,
+		WithRetry(&RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}),
+		WithMiddleware(
+			func(next http.RoundTripper) http.RoundTripper {
+				return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					order = append(order, "outer")
+					return next.RoundTrip(req)
+				})
+			},
+			RequestMutator(func(req *http.Request) error {
+				order = append(order, "inner")
+				req.Header.Add("X-Trace-Id", "trace-1")
+				return nil
+			}),
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Use(ResponseObserver(func(req *http.Request, res *http.Response, err error) {
+		statuses = append(statuses, res.StatusCode)
+	}))
+
+	if _, err := c.HTTP("GET", "/v1/projects", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Join(order, ",") != "outer,inner,outer,inner" {
+		t.Errorf("middlewares expect to run in order on each attempt, but got %s", order)
+	}
+	if len(statuses) != 2 || statuses[0] != 503 || statuses[1] != 200 {
+		t.Errorf("observed statuses are wrong: %v", statuses)
+	}
+}
+
+func TestRequestMutatorError(t *testing.T) {
+	c, err := NewClient("https://api.example.com/", WithMiddleware(RequestMutator(func(req *http.Request) error {
+		return errors.New("denied")
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.HTTP("GET", "/v1/projects", nil); err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("request mutator error expects to abort request, but got %v", err)
+	}
+}
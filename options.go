@@ -0,0 +1,91 @@
+package lolp
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option configures client on NewClient
+type Option func(*Client) error
+
+// WithHTTPClient replaces HTTP client entirely
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) error {
+		if hc == nil {
+			return fmt.Errorf("client: missing http client")
+		}
+		c.HTTPClient = hc
+		return nil
+	}
+}
+
+// WithTransport replaces transport of HTTP client
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) error {
+		if rt == nil {
+			return fmt.Errorf("client: missing transport")
+		}
+		c.HTTPClient.Transport = rt
+		return nil
+	}
+}
+
+// WithTimeout sets timeout for each request of HTTP client
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) error {
+		c.HTTPClient.Timeout = d
+		return nil
+	}
+}
+
+// WithToken sets token for authorization
+func WithToken(token string) Option {
+	return func(c *Client) error {
+		c.Token = token
+		return nil
+	}
+}
+
+// WithUserAgentSuffix appends suffix to User-Agent header
+func WithUserAgentSuffix(suffix string) Option {
+	return func(c *Client) error {
+		if suffix == "" {
+			return nil
+		}
+		c.DefaultHeader.Set("User-Agent", c.DefaultHeader.Get("User-Agent")+" "+suffix)
+		return nil
+	}
+}
+
+// WithRetry sets retry policy
+func WithRetry(p *RetryPolicy) Option {
+	return func(c *Client) error {
+		c.Retry = p
+		return nil
+	}
+}
+
+// WithLogger sets logger
+func WithLogger(l Logger) Option {
+	return func(c *Client) error {
+		c.Logger = l
+		return nil
+	}
+}
+
+// WithEndpoint replaces API endpoint
+func WithEndpoint(u string) Option {
+	return func(c *Client) error {
+		if u == "" {
+			return fmt.Errorf("client: missing url")
+		}
+		parsedURL, err := url.Parse(u)
+		if err != nil {
+			return err
+		}
+		c.URL = parsedURL
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package lolp
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type nopTransport struct{}
+
+func (nopTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, nil
+}
+
+func TestNewClientOptions(t *testing.T) {
+	l := &recordLogger{}
+	p := DefaultRetryPolicy()
+	c, err := NewClient("https://api.example.com/",
+		WithToken("secret"),
+		WithTimeout(3*time.Second),
+		WithUserAgentSuffix("myservice/1.0"),
+		WithRetry(p),
+		WithLogger(l),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Token != "secret" {
+		t.Errorf("token expects secret, but got %s", c.Token)
+	}
+	if c.HTTPClient.Timeout != 3*time.Second {
+		t.Errorf("timeout expects 3s, but got %s", c.HTTPClient.Timeout)
+	}
+	if ua := c.DefaultHeader.Get("User-Agent"); !strings.HasPrefix(ua, "lolp/") || !strings.HasSuffix(ua, ") myservice/1.0") {
+		t.Errorf("User-Agent header is wrong: %s", ua)
+	}
+	if c.Retry != p {
+		t.Errorf("retry policy is not set")
+	}
+	if c.Logger != l {
+		t.Errorf("logger is not set")
+	}
+}
+
+func TestNewClientTransportOptions(t *testing.T) {
+	hc := &http.Client{}
+	c, err := NewClient("https://api.example.com/", WithHTTPClient(hc), WithTransport(nopTransport{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.HTTPClient != hc {
+		t.Errorf("http client is not replaced")
+	}
+	if _, ok := c.HTTPClient.Transport.(nopTransport); !ok {
+		t.Errorf("transport is not replaced: %#v", c.HTTPClient.Transport)
+	}
+
+	if _, err := NewClient("https://api.example.com/", WithTransport(nopTransport{}), WithInsecureSkipVerify()); err == nil {
+		t.Errorf("TLS option on custom transport expects error")
+	}
+	if _, err := NewClient("https://api.example.com/", WithHTTPClient(nil)); err == nil {
+		t.Errorf("nil http client expects error")
+	}
+}
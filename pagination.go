@@ -0,0 +1,149 @@
+package lolp
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+// linkNextPattern finds next page in Link header
+var linkNextPattern = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="?next"?`)
+
+// ListOptions struct for paginated list
+type ListOptions struct {
+	Page   int
+	Limit  int
+	Cursor string
+}
+
+// params returns query parameters
+func (o *ListOptions) params() map[string]string {
+	p := make(map[string]string)
+	if o == nil {
+		return p
+	}
+	if o.Page > 0 {
+		p["page"] = strconv.Itoa(o.Page)
+	}
+	if o.Limit > 0 {
+		p["limit"] = strconv.Itoa(o.Limit)
+	}
+	if o.Cursor != "" {
+		p["cursor"] = o.Cursor
+	}
+	return p
+}
+
+// nextListOptions returns options for next page by Link header, or by page
+// number when the page is just full with limit. It returns nil on last page,
+// and when the page exceeds limit as the server ignores it.
+func nextListOptions(res *http.Response, o *ListOptions, n int) *ListOptions {
+	if m := linkNextPattern.FindStringSubmatch(res.Header.Get("Link")); m != nil {
+		u, err := url.Parse(m[1])
+		if err != nil {
+			return nil
+		}
+		q := u.Query()
+		next := &ListOptions{Cursor: q.Get("cursor")}
+		next.Page, _ = strconv.Atoi(q.Get("page"))
+		next.Limit, _ = strconv.Atoi(q.Get("limit"))
+		return next
+	}
+
+	if o == nil || o.Limit == 0 || n != o.Limit || o.Cursor != "" {
+		return nil
+	}
+	page := o.Page
+	if page == 0 {
+		page = 1
+	}
+
+	return &ListOptions{Page: page + 1, Limit: o.Limit}
+}
+
+// ProjectsPage returns a page of project list, and options for next page
+// which is nil on last page
+func (c *Client) ProjectsPage(ctx context.Context, opts *ListOptions) ([]Project, *ListOptions, error) {
+	res, err := c.HTTPContext(ctx, "GET", "/v1/projects", &RequestOptions{
+		Params: opts.params(),
+		Stream: true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ps []Project
+	if err := decodeJSON(res, &ps); err != nil {
+		return nil, nil, err
+	}
+
+	return ps, nextListOptions(res, opts, len(ps)), nil
+}
+
+// ProjectIterator iterates over project list following pages
+//
+//	it := client.ProjectsIter(ctx, nil)
+//	for it.Next() {
+//		p := it.Project()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type ProjectIterator struct {
+	c    *Client
+	ctx  context.Context
+	next *ListOptions
+	page []Project
+	cur  *Project
+	seen map[string]bool
+	err  error
+}
+
+// ProjectsIter returns iterator of project list from page of opts
+func (c *Client) ProjectsIter(ctx context.Context, opts *ListOptions) *ProjectIterator {
+	if opts == nil {
+		opts = &ListOptions{}
+	}
+	return &ProjectIterator{c: c, ctx: ctx, next: opts, seen: make(map[string]bool)}
+}
+
+// Next advances to next project, and returns false at the end or on error
+func (it *ProjectIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.err != nil || it.next == nil {
+			it.cur = nil
+			return false
+		}
+		it.page, it.next, it.err = it.c.ProjectsPage(it.ctx, it.next)
+		it.dedup()
+	}
+
+	it.cur, it.page = &it.page[0], it.page[1:]
+	return true
+}
+
+// dedup drops projects already seen from page, and stops paging as the
+// server repeats the page
+func (it *ProjectIterator) dedup() {
+	page := it.page[:0]
+	for _, p := range it.page {
+		if p.ID != "" && it.seen[p.ID] {
+			it.next = nil
+			continue
+		}
+		it.seen[p.ID] = true
+		page = append(page, p)
+	}
+	it.page = page
+}
+
+// Project returns current project
+func (it *ProjectIterator) Project() *Project {
+	return it.cur
+}
+
+// Err returns error which stopped iteration
+func (it *ProjectIterator) Err() error {
+	return it.err
+}
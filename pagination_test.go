@@ -0,0 +1,164 @@
+package lolp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func projectPagesHandler(t *testing.T, link bool) func(http.ResponseWriter, *http.Request) {
+	pages := map[string]string{
+		"":  `[{"id":"1"},{"id":"2"}]`,
+		"2": `[{"id":"3"},{"id":"4"}]`,
+		"3": `[{"id":"5"}]`,
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "1" {
+			page = ""
+		}
+		body, ok := pages[page]
+		if !ok {
+			t.Errorf("unexpected page: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if link && page != "3" {
+			next := "2"
+			if page == "2" {
+				next = "3"
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<%s/v1/projects?page=%s>; rel="next", <%s/v1/projects?page=1>; rel="first"`, "http://"+r.Host, next, "http://"+r.Host))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, body)
+	}
+}
+
+func TestProjectsIter(t *testing.T) {
+	cases := []struct {
+		link bool
+		opts *ListOptions
+	}{
+		{true, nil},
+		{false, &ListOptions{Limit: 2}},
+	}
+
+	for _, cc := range cases {
+		s := httptest.NewServer(http.HandlerFunc(projectPagesHandler(t, cc.link)))
+
+		c, err := NewClient(s.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var ids []string
+		it := c.ProjectsIter(context.Background(), cc.opts)
+		for it.Next() {
+			ids = append(ids, it.Project().ID)
+		}
+		s.Close()
+
+		if err := it.Err(); err != nil {
+			t.Errorf("link %t expects no error, but got %s", cc.link, err)
+		}
+		if strings.Join(ids, ",") != "1,2,3,4,5" {
+			t.Errorf("link %t expects all projects, but got %s", cc.link, ids)
+		}
+	}
+}
+
+func TestProjectsPage(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(projectPagesHandler(t, false)))
+	defer s.Close()
+
+	c, err := NewClient(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ps, next, err := c.ProjectsPage(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 2 || next != nil {
+		t.Errorf("page without limit expects to be last, but got %d projects and %#v", len(ps), next)
+	}
+
+	_, next, err = c.ProjectsPage(context.Background(), &ListOptions{Page: 2, Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next == nil || next.Page != 3 || next.Limit != 2 {
+		t.Errorf("next page is wrong: %#v", next)
+	}
+}
+
+func TestProjectsIterError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer s.Close()
+
+	c, err := NewClient(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it := c.ProjectsIter(context.Background(), nil)
+	if it.Next() {
+		t.Errorf("iterator expects to stop on error")
+	}
+	if it.Err() == nil {
+		t.Errorf("iterator expects error")
+	}
+}
+
+func TestProjectsIterIgnoredPage(t *testing.T) {
+	cases := []struct {
+		total int
+		calls int
+	}{
+		{3, 1},
+		{2, 2},
+	}
+
+	for _, cc := range cases {
+		calls := 0
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			ps := make([]string, cc.total)
+			for i := range ps {
+				ps[i] = fmt.Sprintf(`{"id":"%d"}`, i+1)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, "["+strings.Join(ps, ",")+"]")
+		}))
+
+		c, err := NewClient(s.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		n := 0
+		it := c.ProjectsIter(context.Background(), &ListOptions{Limit: 2})
+		for it.Next() && n < 10 {
+			n++
+		}
+		s.Close()
+
+		if err := it.Err(); err != nil {
+			t.Errorf("total %d expects no error, but got %s", cc.total, err)
+		}
+		if n != cc.total || calls != cc.calls {
+			t.Errorf("total %d expects %d projects by %d calls, but got %d by %d", cc.total, cc.total, cc.calls, n, calls)
+		}
+	}
+}
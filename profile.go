@@ -0,0 +1,173 @@
+package lolp
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// ProfileEnvVar for name of profile in config file
+const ProfileEnvVar = "LOLP_PROFILE"
+
+// Profile struct for named settings in config file, which is section of
+// profile name:
+//
+//	[staging]
+//	endpoint = https://api.staging.example.com/
+//	credential_process = vault read -field=token secret/lolp/staging
+//	ca_bundle = /etc/ssl/staging-ca.pem
+//	output = json
+type Profile struct {
+	Name     string `json:"name,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Token, CredentialProcess and the credentials file section of
+	// CredentialsProfile, which defaults to Name, are tried in order
+	Token              string `json:"token,omitempty"`
+	CredentialProcess  string `json:"credential_process,omitempty"`
+	CredentialsProfile string `json:"credentials_profile,omitempty"`
+
+	TLSNoVerify   bool   `json:"tls_noverify,omitempty"`
+	CABundle      string `json:"ca_bundle,omitempty"`
+	ClientCert    string `json:"client_cert,omitempty"`
+	ClientKey     string `json:"client_key,omitempty"`
+	TLSMinVersion string `json:"tls_min_version,omitempty"`
+
+	Proxy   string `json:"proxy,omitempty"`
+	NoProxy string `json:"no_proxy,omitempty"`
+
+	// Output is default output format of CLI such as "text" or "json"
+	Output string `json:"output,omitempty"`
+}
+
+// parseProfile returns profile by keys in section
+func parseProfile(name string, kv map[string]string) *Profile {
+	p := &Profile{
+		Name:               name,
+		Endpoint:           kv["endpoint"],
+		Token:              kv["token"],
+		CredentialProcess:  kv["credential_process"],
+		CredentialsProfile: kv["credentials_profile"],
+		TLSNoVerify:        kv["tls_noverify"] == "true" || kv["tls_noverify"] == "1",
+		CABundle:           kv["ca_bundle"],
+		ClientCert:         kv["client_cert"],
+		ClientKey:          kv["client_key"],
+		TLSMinVersion:      kv["tls_min_version"],
+		Proxy:              kv["proxy"],
+		NoProxy:            kv["no_proxy"],
+		Output:             kv["output"],
+	}
+	if p.CredentialsProfile == "" {
+		p.CredentialsProfile = name
+	}
+	return p
+}
+
+// ProfileNames returns sorted names of profiles
+func (cf *Config) ProfileNames() []string {
+	var names []string
+	for name := range cf.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ActiveProfile returns profile of name, or of LOLP_PROFILE and then of
+// profile key in config file when name is empty. It returns nil without
+// any of them.
+func (cf *Config) ActiveProfile(name string) (*Profile, error) {
+	if name == "" {
+		name = os.Getenv(ProfileEnvVar)
+	}
+	if name == "" {
+		name = cf.Profile
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	p, ok := cf.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("config: profile not found: %s", name)
+	}
+
+	return p, nil
+}
+
+// Options returns client options by profile, which take precedence over
+// environment variables. LOLP_TOKEN is still used when profile has no token.
+func (p *Profile) Options() []Option {
+	var opts []Option
+	if p.Endpoint != "" {
+		opts = append(opts, WithEndpoint(p.Endpoint))
+	}
+
+	if p.TLSNoVerify {
+		opts = append(opts, WithInsecureSkipVerify())
+	}
+	if p.CABundle != "" {
+		opts = append(opts, WithCABundle(p.CABundle))
+	}
+	if p.ClientCert != "" || p.ClientKey != "" {
+		opts = append(opts, func(c *Client) error {
+			if p.ClientCert == "" || p.ClientKey == "" {
+				return fmt.Errorf("config: both client_cert and client_key are required in profile: %s", p.Name)
+			}
+			return WithClientCertificate(p.ClientCert, p.ClientKey)(c)
+		})
+	}
+	if p.TLSMinVersion != "" {
+		opts = append(opts, func(c *Client) error {
+			v, err := ParseTLSVersion(p.TLSMinVersion)
+			if err != nil {
+				return err
+			}
+			return WithMinTLSVersion(v)(c)
+		})
+	}
+
+	if p.Proxy != "" {
+		opts = append(opts, WithProxy(p.Proxy, p.NoProxy))
+	}
+
+	// credentials come after endpoint for netrc entry of its host, and token
+	// of environment variable is kept without token in profile
+	opts = append(opts, func(c *Client) error {
+		if p.Token != "" {
+			c.Token = p.Token
+		}
+		c.Credentials = p.CredentialProvider(c.URL.Hostname())
+		return nil
+	})
+
+	return opts
+}
+
+// CredentialProvider returns provider trying token, credential process and
+// credentials file of profile in order, and then DefaultCredentialChain for
+// host as fallback
+func (p *Profile) CredentialProvider(host string) CredentialProvider {
+	return ChainCredentials(
+		StaticCredentials(p.Token),
+		ProcessCredentials(p.CredentialProcess),
+		FileCredentials("", p.CredentialsProfile),
+		DefaultCredentialChain(host),
+	)
+}
+
+// UseProfile sets profile key in config file to name of existing profile.
+// Comments in the file are not kept.
+func UseProfile(path, name string) error {
+	sections, err := readINI(path)
+	if err != nil {
+		return err
+	}
+	if _, ok := sections[name]; !ok || name == "" {
+		return fmt.Errorf("config: profile not found: %s", name)
+	}
+
+	sections[""]["profile"] = name
+
+	return writeINI(path, sections)
+}
@@ -0,0 +1,131 @@
+package lolp
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestActiveProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lolp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := filepath.Join(dir, "config")
+	in := "profile = production\n\n[production]\nendpoint = https://api.mc.lolipop.jp/\n\n[staging]\nendpoint = https://api.staging.example.com/\noutput = json\n"
+	if err := ioutil.WriteFile(p, []byte(in), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cf, err := LoadConfig(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if names := cf.ProfileNames(); len(names) != 2 || names[0] != "production" || names[1] != "staging" {
+		t.Errorf("profile names are wrong: %v", names)
+	}
+
+	cases := []struct {
+		name     string
+		env      string
+		expected string
+	}{
+		{"", "", "production"},
+		{"", "staging", "staging"},
+		{"production", "staging", "production"},
+	}
+	for _, cc := range cases {
+		os.Setenv(ProfileEnvVar, cc.env)
+		pf, err := cf.ActiveProfile(cc.name)
+		os.Unsetenv(ProfileEnvVar)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pf.Name != cc.expected {
+			t.Errorf("active profile by %q and env %q\nexpected: %s\nactual: %s", cc.name, cc.env, cc.expected, pf.Name)
+		}
+	}
+
+	if pf, _ := cf.ActiveProfile("staging"); pf.Output != "json" || pf.CredentialsProfile != "staging" {
+		t.Errorf("staging profile is wrong: %#v", pf)
+	}
+	if _, err := cf.ActiveProfile("unknown"); err == nil {
+		t.Errorf("unknown profile expects error")
+	}
+
+	if err := UseProfile(p, "staging"); err != nil {
+		t.Fatal(err)
+	}
+	if cf, _ = LoadConfig(p); cf.Profile != "staging" || len(cf.Profiles) != 2 {
+		t.Errorf("config after use is wrong: %#v", cf)
+	}
+	if err := UseProfile(p, "unknown"); err == nil {
+		t.Errorf("use of unknown profile expects error")
+	}
+}
+
+func TestProfileOptions(t *testing.T) {
+	os.Setenv(TokenEnvVar, "env-token")
+	defer os.Unsetenv(TokenEnvVar)
+	os.Setenv(CredentialsEnvVar, "testdata/not-exist")
+	defer os.Unsetenv(CredentialsEnvVar)
+	os.Setenv("NETRC", "testdata/not-exist")
+	defer os.Unsetenv("NETRC")
+
+	pf := parseProfile("staging", map[string]string{
+		"endpoint":        "https://api.staging.example.com/",
+		"token":           "staging-token",
+		"tls_min_version": "1.2",
+	})
+	c, err := NewFromEnv(pf.Options()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.URL.Host != "api.staging.example.com" {
+		t.Errorf("endpoint is wrong: %s", c.URL)
+	}
+	if c.Token != "staging-token" {
+		t.Errorf("token of profile expects to take precedence, but got %s", c.Token)
+	}
+	if v := c.HTTPClient.Transport.(*http.Transport).TLSClientConfig.MinVersion; v != tls.VersionTLS12 {
+		t.Errorf("min TLS version is wrong: %x", v)
+	}
+
+	var auth string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		io.WriteString(w, `[]`)
+	}))
+	defer s.Close()
+
+	pf = parseProfile("prod", map[string]string{"endpoint": s.URL})
+	c, err = NewFromEnv(pf.Options()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Projects(); err != nil {
+		t.Fatal(err)
+	}
+	if auth != "Bearer env-token" {
+		t.Errorf("profile without token expects to keep token of environment variable, but got %q", auth)
+	}
+
+	c.Token = ""
+	if creds, err := c.Credentials.Retrieve(context.Background()); err != nil || creds.Token != "env-token" {
+		t.Errorf("credentials of profile expect to fall back to environment variable, but got %#v, %v", creds, err)
+	}
+
+	pf = parseProfile("broken", map[string]string{"client_cert": "cert.pem"})
+	if _, err := NewClient("https://api.mc.lolipop.jp/", pf.Options()...); err == nil {
+		t.Errorf("client cert without key expects error")
+	}
+}
@@ -2,10 +2,10 @@ package lolp
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"time"
 )
 
@@ -43,7 +43,7 @@ type SSH struct {
 // ProjectNew struct on create
 type ProjectNew struct {
 	Name          string                 `json:"name,omitempty"`
-	Kind          string                 `json:"kind,omitempty""`
+	Kind          string                 `json:"kind,omitempty"`
 	SubDomain     string                 `json:"sub_domain,omitempty"`
 	CustomDomains []string               `json:"custom_domains,omitempty"`
 	Payload       map[string]interface{} `json:"payload,omitempty"`
@@ -53,11 +53,21 @@ type ProjectNew struct {
 type ProjectCreateResponse struct {
 	ID     string `json:"id"`
 	Domain string `json:"domain"`
+	// Replayed is whether project was created by earlier request with the
+	// same idempotency key
+	Replayed bool `json:"-"`
 }
 
 // Projects returns project list
 func (c *Client) Projects() (*[]Project, error) {
-	res, err := c.HTTP("GET", "/v1/projects", nil)
+	return c.ProjectsContext(context.Background())
+}
+
+// ProjectsContext returns project list with context
+func (c *Client) ProjectsContext(ctx context.Context) (*[]Project, error) {
+	res, err := c.HTTPContext(ctx, "GET", "/v1/projects", &RequestOptions{
+		Stream: true,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +82,12 @@ func (c *Client) Projects() (*[]Project, error) {
 
 // Project returns a project by sub-domain name
 func (c *Client) Project(name string) (*Project, error) {
-	res, err := c.HTTP("GET", `/v1/projects/`+name, nil)
+	return c.ProjectContext(context.Background(), name)
+}
+
+// ProjectContext returns a project by sub-domain name with context
+func (c *Client) ProjectContext(ctx context.Context, name string) (*Project, error) {
+	res, err := c.HTTPContext(ctx, "GET", `/v1/projects/`+name, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +102,11 @@ func (c *Client) Project(name string) (*Project, error) {
 
 // CreateProject creates project with kind
 func (c *Client) CreateProject(p *ProjectNew) (*ProjectCreateResponse, error) {
+	return c.CreateProjectContext(context.Background(), p)
+}
+
+// CreateProjectContext creates project with kind and context
+func (c *Client) CreateProjectContext(ctx context.Context, p *ProjectNew) (*ProjectCreateResponse, error) {
 	if len(p.Kind) == 0 {
 		return nil, fmt.Errorf("client: missing kind")
 	}
@@ -95,16 +115,16 @@ func (c *Client) CreateProject(p *ProjectNew) (*ProjectCreateResponse, error) {
 	if err != nil {
 		return nil, err
 	}
-	log.Printf("[DEBUG] request body: %s", body)
+	c.logger().Debug("request body", "path", "/v1/projects", "body", string(body))
 
-	res, err := c.HTTP("POST", "/v1/projects", &RequestOptions{
+	res, err := c.HTTPContext(ctx, "POST", "/v1/projects", &RequestOptions{
 		Body: bytes.NewReader(body),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	var r ProjectCreateResponse
+	r := ProjectCreateResponse{Replayed: Replayed(res)}
 	if err := decodeJSON(res, &r); err != nil {
 		return nil, err
 	}
@@ -114,7 +134,12 @@ func (c *Client) CreateProject(p *ProjectNew) (*ProjectCreateResponse, error) {
 
 // DeleteProject deletes project by project sub-domain name
 func (c *Client) DeleteProject(name string) error {
-	_, err := c.HTTP("DELETE", `/v1/projects/`+name, nil)
+	return c.DeleteProjectContext(context.Background(), name)
+}
+
+// DeleteProjectContext deletes project by project sub-domain name with context
+func (c *Client) DeleteProjectContext(ctx context.Context, name string) error {
+	_, err := c.HTTPContext(ctx, "DELETE", `/v1/projects/`+name, nil)
 	if err != nil {
 		return err
 	}
@@ -124,7 +149,12 @@ func (c *Client) DeleteProject(name string) error {
 
 // EnableAutoscaling enable autoscaling by project sub-domain name
 func (c *Client) EnableAutoscaling(name string) error {
-	_, err := c.HTTP("PUT", `/v1/projects/`+name+`/autoscaling/enable`, nil)
+	return c.EnableAutoscalingContext(context.Background(), name)
+}
+
+// EnableAutoscalingContext enable autoscaling by project sub-domain name with context
+func (c *Client) EnableAutoscalingContext(ctx context.Context, name string) error {
+	_, err := c.HTTPContext(ctx, "PUT", `/v1/projects/`+name+`/autoscaling/enable`, nil)
 	if err != nil {
 		return err
 	}
@@ -134,7 +164,12 @@ func (c *Client) EnableAutoscaling(name string) error {
 
 // DisableAutoscaling disable autoscaling by project sub-domain name
 func (c *Client) DisableAutoscaling(name string) error {
-	_, err := c.HTTP("PUT", `/v1/projects/`+name+`/autoscaling/disable`, nil)
+	return c.DisableAutoscalingContext(context.Background(), name)
+}
+
+// DisableAutoscalingContext disable autoscaling by project sub-domain name with context
+func (c *Client) DisableAutoscalingContext(ctx context.Context, name string) error {
+	_, err := c.HTTPContext(ctx, "PUT", `/v1/projects/`+name+`/autoscaling/disable`, nil)
 	if err != nil {
 		return err
 	}
@@ -143,7 +178,12 @@ func (c *Client) DisableAutoscaling(name string) error {
 }
 
 func (c *Client) GetEnvironmentVariables(name string) (string, error) {
-	res, err := c.HTTP("GET", `/v1/projects/`+name+`/environment-variables`, nil)
+	return c.GetEnvironmentVariablesContext(context.Background(), name)
+}
+
+// GetEnvironmentVariablesContext returns environment variables of project with context
+func (c *Client) GetEnvironmentVariablesContext(ctx context.Context, name string) (string, error) {
+	res, err := c.HTTPContext(ctx, "GET", `/v1/projects/`+name+`/environment-variables`, nil)
 	if err != nil {
 		return "", err
 	}
@@ -165,12 +205,17 @@ type UpdateEnvironmentVariablesParam struct {
 }
 
 func (c *Client) UpdateEnvironmentVariables(name string, params []UpdateEnvironmentVariablesParam) error {
+	return c.UpdateEnvironmentVariablesContext(context.Background(), name, params)
+}
+
+// UpdateEnvironmentVariablesContext updates environment variables of project with context
+func (c *Client) UpdateEnvironmentVariablesContext(ctx context.Context, name string, params []UpdateEnvironmentVariablesParam) error {
 	body, err := json.Marshal(params)
 	if err != nil {
 		return err
 	}
 
-	_, err = c.HTTP("PUT", `/v1/projects/`+name+`/environment-variables`, &RequestOptions{
+	_, err = c.HTTPContext(ctx, "PUT", `/v1/projects/`+name+`/environment-variables`, &RequestOptions{
 		Body: bytes.NewReader(body),
 	})
 	if err != nil {
@@ -0,0 +1,75 @@
+package lolp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestNoProxyMatch(t *testing.T) {
+	np := parseNoProxy("localhost, .internal.example.com,api.example.org:8443, 10.0.0.0/8,192.168.1.1")
+
+	cases := []struct {
+		url      string
+		expected bool
+	}{
+		{"http://localhost/v1", true},
+		{"https://foo.internal.example.com/", true},
+		{"https://internal.example.com/", true},
+		{"https://api.example.org:8443/", true},
+		{"https://api.example.org/", false},
+		{"http://10.1.2.3/", true},
+		{"http://192.168.1.1:8080/", true},
+		{"http://192.168.1.2/", false},
+		{"https://api.mc.lolipop.jp/", false},
+	}
+
+	for _, cc := range cases {
+		u, err := url.Parse(cc.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := np.match(u); actual != cc.expected {
+			t.Errorf("%s expects %t, but got %t", cc.url, cc.expected, actual)
+		}
+	}
+
+	if !parseNoProxy("*").match(&url.URL{Scheme: "https", Host: "example.com"}) {
+		t.Errorf("* expects to match all hosts")
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	var proxyAuth, requested string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxyAuth = r.Header.Get("Proxy-Authorization")
+		requested = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	u, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u.User = url.UserPassword("user", "pass")
+
+	c, err := NewClient("http://api.example.com/", WithProxy(u.String(), "localhost"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.HTTP("GET", "/v1/projects", nil); err != nil {
+		t.Fatal(err)
+	}
+	if requested != "http://api.example.com/v1/projects" {
+		t.Errorf("request expects to be sent via proxy, but got %s", requested)
+	}
+	if proxyAuth != "Basic dXNlcjpwYXNz" {
+		t.Errorf("Proxy-Authorization header is wrong: %s", proxyAuth)
+	}
+
+	if _, err := NewClient("http://api.example.com/", WithProxy("ftp://proxy.example.com", "")); err == nil {
+		t.Errorf("unsupported proxy scheme expects error")
+	}
+}
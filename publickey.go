@@ -2,9 +2,9 @@ package lolp
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 )
 
 type PublicKey struct {
@@ -14,6 +14,11 @@ type PublicKey struct {
 
 // AddPublicKey add OpenSSH public key
 func (c *Client) AddPublicKey(p *PublicKey) (*PublicKey, error) {
+	return c.AddPublicKeyContext(context.Background(), p)
+}
+
+// AddPublicKeyContext add OpenSSH public key with context
+func (c *Client) AddPublicKeyContext(ctx context.Context, p *PublicKey) (*PublicKey, error) {
 	if len(p.Name) == 0 {
 		return nil, fmt.Errorf("client: missing name")
 	}
@@ -25,9 +30,9 @@ func (c *Client) AddPublicKey(p *PublicKey) (*PublicKey, error) {
 	if err != nil {
 		return nil, err
 	}
-	log.Printf("[DEBUG] request body: %s", body)
+	c.logger().Debug("request body", "path", "/v1/pubkeys", "body", string(body))
 
-	res, err := c.HTTP("POST", "/v1/pubkeys", &RequestOptions{
+	res, err := c.HTTPContext(ctx, "POST", "/v1/pubkeys", &RequestOptions{
 		Body: bytes.NewReader(body),
 	})
 	if err != nil {
@@ -44,11 +49,16 @@ func (c *Client) AddPublicKey(p *PublicKey) (*PublicKey, error) {
 
 // DeletePublicKey delete OpenSSH public key
 func (c *Client) DeletePublicKey(name string) error {
+	return c.DeletePublicKeyContext(context.Background(), name)
+}
+
+// DeletePublicKeyContext delete OpenSSH public key with context
+func (c *Client) DeletePublicKeyContext(ctx context.Context, name string) error {
 	if len(name) == 0 {
 		return fmt.Errorf("client: missing name")
 	}
 
-	_, err := c.HTTP("DELETE", "/v1/pubkeys/"+name, nil)
+	_, err := c.HTTPContext(ctx, "DELETE", "/v1/pubkeys/"+name, nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,173 @@
+package lolp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RecorderMode for Recorder
+type RecorderMode int
+
+const (
+	// ModeReplay serves responses from fixtures without network
+	ModeReplay RecorderMode = iota
+
+	// ModeRecord sends requests and writes fixtures
+	ModeRecord
+)
+
+// recordedMeta struct for status and headers beside response fixture
+type recordedMeta struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+}
+
+// Recorder is http.RoundTripper recording API interactions as fixtures and
+// replaying them. Fixtures use the layout of this repository's testdata:
+//
+//	<Dir>/<path>/<METHOD>--<Name>.request.json
+//	<Dir>/<path>/<METHOD>--<Name>.response.json
+//	<Dir>/<path>/<METHOD>--<Name>.meta.json
+//
+// where meta has status code and headers, and replay defaults to 200 OK
+// without it. Secrets in bodies and headers are masked on recording.
+type Recorder struct {
+	Dir  string
+	Name string
+	Mode RecorderMode
+	// Transport sends requests on recording, http.DefaultTransport when nil
+	Transport http.RoundTripper
+}
+
+// NewRecorder returns recorder for fixtures named name in dir
+func NewRecorder(dir, name string, mode RecorderMode) *Recorder {
+	return &Recorder{Dir: dir, Name: name, Mode: mode}
+}
+
+// fixturePath returns path of fixture by request and kind
+func (r *Recorder) fixturePath(req *http.Request, kind string) string {
+	f := fmt.Sprintf("%s--%s.%s.json", req.Method, r.Name, kind)
+	return filepath.Join(r.Dir, filepath.FromSlash(req.URL.RequestURI()), f)
+}
+
+// RoundTrip records or replays request
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.Mode == ModeRecord {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+// replay returns response by fixtures
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	p := r.fixturePath(req, "response")
+	body, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("recorder: fixture not found: %s", p)
+	}
+	body = bytes.TrimSuffix(body, []byte("\n"))
+
+	meta := &recordedMeta{StatusCode: http.StatusOK}
+	if b, err := ioutil.ReadFile(r.fixturePath(req, "meta")); err == nil {
+		if err := json.Unmarshal(b, meta); err != nil {
+			return nil, fmt.Errorf("recorder: %s", err)
+		}
+	}
+	if meta.Header == nil {
+		meta.Header = http.Header{"Content-Type": []string{"application/json"}}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", meta.StatusCode, http.StatusText(meta.StatusCode)),
+		StatusCode:    meta.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        meta.Header,
+		Body:          &bytesReadCloser{bytes.NewBuffer(body)},
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// record sends request and writes fixtures with secrets masked
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+		req = req.Clone(req.Context())
+		req.Body = ioutil.NopCloser(bytes.NewReader(b))
+	}
+
+	t := r.Transport
+	if t == nil {
+		t = http.DefaultTransport
+	}
+	res, err := t.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = &bytesReadCloser{bytes.NewBuffer(resBody)}
+
+	meta, err := json.MarshalIndent(&recordedMeta{
+		StatusCode: res.StatusCode,
+		Header:     scrubHeader(res.Header),
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{
+		"request":  redactBody(req.URL.Path, reqBody),
+		"response": redactBody(req.URL.Path, resBody),
+		"meta":     meta,
+	}
+	for kind, b := range files {
+		if err := writeFixture(r.fixturePath(req, kind), b); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// scrubHeader returns recorded header without secrets and volatile values
+func scrubHeader(h http.Header) http.Header {
+	out := redactHeader(h)
+	for _, k := range []string{"Date", "Content-Length"} {
+		out.Del(k)
+	}
+	return out
+}
+
+// writeFixture writes body with trailing newline
+func writeFixture(p string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	if len(b) > 0 && !strings.HasSuffix(string(b), "\n") {
+		b = append(b, '\n')
+	}
+	return ioutil.WriteFile(p, b, 0644)
+}
@@ -0,0 +1,92 @@
+package lolp
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorderReplay(t *testing.T) {
+	c, err := NewClient("https://api.example.com/", WithTransport(NewRecorder("testdata", "ok", ModeReplay)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := c.Project("rails-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != "58b22c80-5c64-41ed-ac51-7ca0c695e592" || p.SSH.Port != 12345 {
+		t.Errorf("replayed project is wrong: %#v", p)
+	}
+
+	if _, err := c.Project("not-exist"); err == nil || !strings.Contains(err.Error(), "fixture not found") {
+		t.Errorf("missing fixture expects error, but got %v", err)
+	}
+}
+
+func TestRecorderRecord(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		if r.URL.Path == "/v1/projects/gone" {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"errors":["Not found"]}`)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `"issued-token"`)
+	}))
+	defer s.Close()
+
+	dir, err := ioutil.TempDir("", "lolp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c, err := NewClient(s.URL, WithTransport(NewRecorder(dir, "ok", ModeRecord)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := c.Authenticate("foo@example.com", "Secret#Gopher123?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "issued-token" {
+		t.Errorf("recording expects to return real response, but got %s", token)
+	}
+	if _, err := c.Project("gone"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("recording expects real error, but got %v", err)
+	}
+
+	for _, f := range []string{"v1/authenticate/POST--ok.request.json", "v1/authenticate/POST--ok.response.json", "v1/authenticate/POST--ok.meta.json"} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, f))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, secret := range []string{"Secret#Gopher123?", "issued-token", "session=secret"} {
+			if strings.Contains(string(b), secret) {
+				t.Errorf("%s expects secrets to be scrubbed, but got %s", f, b)
+			}
+		}
+	}
+
+	c, err = NewClient(s.URL, WithTransport(NewRecorder(dir, "ok", ModeReplay)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	if _, err := c.Project("gone"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("replay expects recorded status, but got %v", err)
+	}
+	if _, err := c.Authenticate("foo@example.com", "Secret#Gopher123?"); err != nil {
+		t.Errorf("replay expects to succeed, but failed: %s", err)
+	}
+}
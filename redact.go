@@ -0,0 +1,170 @@
+package lolp
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// redacted replaces secret values in logs
+const redacted = "[REDACTED]"
+
+// secretHeaders are masked in logged headers
+var secretHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// secretFields are masked in logged JSON bodies at any depth
+var secretFields = []string{
+	"password",
+	"db_password",
+	"dbPassword",
+	"token",
+	"secret",
+	"otp",
+}
+
+// secretFieldPattern finds secret string fields in truncated JSON
+var secretFieldPattern = regexp.MustCompile(`(?i)("(?:` + strings.Join(secretFields, "|") + `)"\s*:\s*)"(?:[^"\\]|\\.)*"?`)
+
+// envVarPath has variable values in request and response bodies
+const envVarPath = "/environment-variables"
+
+// envVarFields are kept as is in environment-variables bodies
+var envVarFields = []string{"key", "method"}
+
+// redactLogger masks headers and bodies before passing to Logger
+type redactLogger struct {
+	l Logger
+}
+
+func (r *redactLogger) Debug(msg string, kv ...interface{}) { r.l.Debug(msg, redactFields(kv)...) }
+func (r *redactLogger) Info(msg string, kv ...interface{})  { r.l.Info(msg, redactFields(kv)...) }
+func (r *redactLogger) Warn(msg string, kv ...interface{})  { r.l.Warn(msg, redactFields(kv)...) }
+func (r *redactLogger) Error(msg string, kv ...interface{}) { r.l.Error(msg, redactFields(kv)...) }
+
+// redactFields returns copy of key-value pairs with secrets masked
+func redactFields(kv []interface{}) []interface{} {
+	var spath string
+	for i := 0; i+1 < len(kv); i += 2 {
+		if kv[i] == "path" {
+			spath, _ = kv[i+1].(string)
+		}
+	}
+
+	out := make([]interface{}, len(kv))
+	copy(out, kv)
+	for i := 1; i < len(out); i += 2 {
+		switch v := out[i].(type) {
+		case http.Header:
+			out[i] = redactHeader(v)
+		case string:
+			if out[i-1] == "body" {
+				out[i] = string(redactBody(spath, []byte(v)))
+			}
+		case []byte:
+			if out[i-1] == "body" {
+				out[i] = string(redactBody(spath, v))
+			}
+		}
+	}
+
+	return out
+}
+
+// redactHeader returns copy of header with secret values masked
+func redactHeader(h http.Header) http.Header {
+	out := h.Clone()
+	for _, k := range secretHeaders {
+		if _, ok := out[http.CanonicalHeaderKey(k)]; ok {
+			out.Set(k, redacted)
+		}
+	}
+	return out
+}
+
+// redactBody returns JSON body with secret values masked, or body as is
+// when it has no secrets. Bodies of
+// environment variables mask all values, and a bare JSON string such as
+// an issued token is masked entirely. Body which is not valid JSON, such
+// as truncated one, is masked by field names only.
+func redactBody(spath string, body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return secretFieldPattern.ReplaceAll(body, []byte(`$1"`+redacted+`"`))
+	}
+
+	if _, ok := v.(string); ok {
+		return []byte(`"` + redacted + `"`)
+	}
+
+	v, changed := redactValue(v, strings.Contains(spath, envVarPath))
+	if !changed {
+		return body
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+
+	return b
+}
+
+// redactValue masks secret fields recursively, and reports whether masked
+func redactValue(v interface{}, all bool) (interface{}, bool) {
+	changed := false
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		for k, e := range vv {
+			if _, ok := e.(string); ok {
+				if isSecretField(k) || all && !isEnvVarField(k) {
+					vv[k] = redacted
+					changed = true
+				}
+				continue
+			}
+			var c bool
+			vv[k], c = redactValue(e, all)
+			changed = changed || c
+		}
+	case []interface{}:
+		for i, e := range vv {
+			var c bool
+			vv[i], c = redactValue(e, all)
+			changed = changed || c
+		}
+	case string:
+		if all {
+			return redacted, true
+		}
+	}
+	return v, changed
+}
+
+// isSecretField returns whether JSON key holds a secret
+func isSecretField(k string) bool {
+	for _, f := range secretFields {
+		if strings.EqualFold(k, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEnvVarField returns whether JSON key is kept in environment-variables
+func isEnvVarField(k string) bool {
+	for _, f := range envVarFields {
+		if k == f {
+			return true
+		}
+	}
+	return false
+}
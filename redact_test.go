@@ -0,0 +1,95 @@
+package lolp
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedactHeader(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer secret")
+	h.Set("Content-Type", "application/json")
+
+	r := redactHeader(h)
+	if a := r.Get("Authorization"); a != redacted {
+		t.Errorf("Authorization header expects redacted, but got %s", a)
+	}
+	if ct := r.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type header expects as is, but got %s", ct)
+	}
+	if a := h.Get("Authorization"); a != "Bearer secret" {
+		t.Errorf("original header expects not to be modified, but got %s", a)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	cases := []struct {
+		path     string
+		body     string
+		expected string
+	}{
+		{
+			"/v1/authenticate",
+			`{"username":"foo@example.com","password":"Secret#Gopher123?"}`,
+			`{"password":"[REDACTED]","username":"foo@example.com"}`,
+		},
+		{
+			"/v1/authenticate",
+			`"eyJhbGciOiJIUzI1NiJ9.e30.sig"`,
+			`"[REDACTED]"`,
+		},
+		{
+			"/v1/projects",
+			`{"kind":"wordpress","payload":{"username":"foo","password":"Secret#Gopher123?"},"db_password":"Secret#Gopher123?"}`,
+			`{"db_password":"[REDACTED]","kind":"wordpress","payload":{"password":"[REDACTED]","username":"foo"}}`,
+		},
+		{
+			"/v1/projects/rails-1/environment-variables",
+			`[{"method":"create","variable":{"key":"API_KEY","value":"s3cr3t"}}]`,
+			`[{"method":"create","variable":{"key":"API_KEY","value":"[REDACTED]"}}]`,
+		},
+		{
+			"/v1/projects/rails-1/environment-variables",
+			`{"API_KEY":"s3cr3t"}`,
+			`{"API_KEY":"[REDACTED]"}`,
+		},
+		{
+			"/v1/projects",
+			`not json`,
+			`not json`,
+		},
+		{
+			"/v1/projects",
+			`[{"name":"foo","db_password":"Secret#Gopher123?"},{"name":"bar","Password":"Sec`,
+			`[{"name":"foo","db_password":"[REDACTED]"},{"name":"bar","Password":"[REDACTED]"`,
+		},
+	}
+
+	for _, cc := range cases {
+		actual := string(redactBody(cc.path, []byte(cc.body)))
+		if actual != cc.expected {
+			t.Errorf("%s body\nexpected: %s\nactual: %s", cc.path, cc.expected, actual)
+		}
+	}
+}
+
+func TestClientLoggerRedaction(t *testing.T) {
+	l := &recordLogger{}
+	c, err := NewClient("https://api.example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Logger = l
+	c.Token = "secret-token"
+
+	if _, err := c.Request("GET", "/v1/projects", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range l.lines {
+		if strings.Contains(line, "secret-token") {
+			t.Errorf("token expects to be redacted, but logged: %s", line)
+		}
+	}
+}
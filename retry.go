@@ -0,0 +1,234 @@
+package lolp
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultMaxAttempts for retry
+	defaultMaxAttempts = 4
+
+	// defaultMinBackoff for retry
+	defaultMinBackoff = 500 * time.Millisecond
+
+	// defaultMaxBackoff for retry
+	defaultMaxBackoff = 30 * time.Second
+)
+
+// idempotentVerbs are retried by default
+var idempotentVerbs = []string{"GET", "HEAD", "OPTIONS", "PUT", "DELETE"}
+
+// RetryPolicy struct for retrying transient failures
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first one
+	MaxAttempts int
+	// MinBackoff is the wait before the first retry
+	MinBackoff time.Duration
+	// MaxBackoff caps the wait between attempts, and retry gives up when
+	// Retry-After asks to wait longer
+	MaxBackoff time.Duration
+	// Verbs are retryable HTTP methods, idempotent ones and ones with
+	// idempotency key when empty
+	Verbs []string
+}
+
+// DefaultRetryPolicy returns retry policy with default values
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: defaultMaxAttempts,
+		MinBackoff:  defaultMinBackoff,
+		MaxBackoff:  defaultMaxBackoff,
+	}
+}
+
+// maxAttempts returns attempts with default
+func (p *RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// retryable returns whether request can be sent again, where request with
+// idempotency key is regarded as idempotent
+func (p *RetryPolicy) retryable(req *http.Request) bool {
+	verbs := p.Verbs
+	if len(verbs) == 0 {
+		verbs = idempotentVerbs
+		if req.Header.Get(IdempotencyKeyHeader) != "" && contains(mutatingVerbs, req.Method) {
+			verbs = mutatingVerbs
+		}
+	}
+
+	if !contains(verbs, req.Method) {
+		return false
+	}
+
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// shouldRetry returns whether response is transient failure
+func (p *RetryPolicy) shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		return temporaryError(err)
+	}
+
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests:
+		return true
+	case res.StatusCode == http.StatusNotImplemented:
+		return false
+	case res.StatusCode >= 500:
+		return true
+	default:
+		return false
+	}
+}
+
+// temporaryError returns whether transport error is by network such as
+// timeout, refused or reset connection, but not by TLS verification or
+// invalid request which fails again
+func temporaryError(err error) bool {
+	var (
+		authority x509.UnknownAuthorityError
+		invalid   x509.CertificateInvalidError
+		hostname  x509.HostnameError
+		record    tls.RecordHeaderError
+	)
+	if errors.As(err, &authority) || errors.As(err, &invalid) || errors.As(err, &hostname) || errors.As(err, &record) {
+		return false
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var op *net.OpError
+	if errors.As(err, &op) {
+		return true
+	}
+
+	var ne net.Error
+	return errors.As(err, &ne) && ne.Timeout()
+}
+
+// backoff returns wait duration before next attempt, and false if the
+// server asks to wait longer than MaxBackoff
+func (p *RetryPolicy) backoff(attempt int, res *http.Response) (time.Duration, bool) {
+	min, max := p.MinBackoff, p.MaxBackoff
+	if min <= 0 {
+		min = defaultMinBackoff
+	}
+	if max <= 0 {
+		max = defaultMaxBackoff
+	}
+
+	if res != nil {
+		if d, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			return d, d <= max
+		}
+	}
+
+	d := min
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+
+	// equal jitter keeps at least half of the backoff
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1)), true
+}
+
+// parseRetryAfter parses Retry-After header by seconds or HTTP-date
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if s, err := strconv.Atoi(v); err == nil {
+		if s < 0 {
+			return 0, false
+		}
+		return time.Duration(s) * time.Second, true
+	}
+
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	d := time.Until(t)
+	if d < 0 {
+		d = 0
+	}
+
+	return d, true
+}
+
+// do sends request with retry policy
+func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	p := c.Retry
+	if p == nil || !p.retryable(req) {
+		return c.roundTrip(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		res, err := c.roundTrip(req)
+		if attempt >= p.maxAttempts() || ctx.Err() != nil || !p.shouldRetry(res, err) {
+			return res, err
+		}
+
+		wait, ok := p.backoff(attempt, res)
+		if !ok {
+			return res, err
+		}
+
+		if err != nil {
+			c.logger().Warn("retry", "verb", req.Method, "path", req.URL.Path, "attempt", attempt, "wait", wait, "error", err)
+		} else {
+			c.logger().Warn("retry", "verb", req.Method, "path", req.URL.Path, "attempt", attempt, "wait", wait, "status", res.StatusCode)
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+
+		if req, err = rewind(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// rewind returns copy of request with fresh body
+func rewind(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody == nil {
+		return r, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = body
+
+	return r, nil
+}
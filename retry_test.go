@@ -0,0 +1,127 @@
+package lolp
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func flakyHandler(t *testing.T, failures int32, status int, calls *int32) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			panic(err.Error())
+		}
+		if r.Method == "PUT" && string(body) != `{"ok":true}` {
+			t.Errorf("request body is not rewound: %s", body)
+		}
+
+		if atomic.AddInt32(calls, 1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	cases := []struct {
+		verb      string
+		failures  int32
+		status    int
+		wantCalls int32
+		wantErr   bool
+	}{
+		{"GET", 2, http.StatusServiceUnavailable, 3, false},
+		{"PUT", 1, http.StatusTooManyRequests, 2, false},
+		{"GET", 5, http.StatusBadGateway, 3, true},
+		{"POST", 1, http.StatusServiceUnavailable, 2, false},
+		{"GET", 1, http.StatusNotFound, 1, true},
+	}
+
+	for _, cc := range cases {
+		var calls int32
+		s := httptest.NewServer(http.HandlerFunc(flakyHandler(t, cc.failures, cc.status, &calls)))
+
+		c, err := NewClient(s.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Retry = &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+
+		_, err = c.HTTP(cc.verb, "/v1/projects", &RequestOptions{
+			Body: strings.NewReader(`{"ok":true}`),
+		})
+		s.Close()
+
+		if cc.wantErr && err == nil {
+			t.Errorf("%s with %d expects error, but succeeded", cc.verb, cc.status)
+		}
+		if !cc.wantErr && err != nil {
+			t.Errorf("%s with %d expects success, but failed: %s", cc.verb, cc.status, err)
+		}
+		if calls != cc.wantCalls {
+			t.Errorf("%s with %d expects %d calls, but got %d", cc.verb, cc.status, cc.wantCalls, calls)
+		}
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, max := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		max *= time.Millisecond
+		d, ok := p.backoff(attempt+1, nil)
+		if !ok || d < max/2 || d > max {
+			t.Errorf("attempt %d backoff expects between %s and %s, but got %s", attempt+1, max/2, max, d)
+		}
+	}
+
+	res := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	if d, ok := p.backoff(1, res); ok || d != 3*time.Second {
+		t.Errorf("Retry-After beyond max backoff expects to give up, but got %s", d)
+	}
+
+	res.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	if d, ok := p.backoff(1, res); !ok || d != 0 {
+		t.Errorf("Retry-After in the past expects no wait, but got %s", d)
+	}
+}
+
+func TestRetryTransportError(t *testing.T) {
+	untrusted := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer untrusted.Close()
+	closed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	closed.Close()
+
+	cases := []struct {
+		url       string
+		wantCalls int32
+	}{
+		{closed.URL, 3},
+		{untrusted.URL, 1},
+	}
+
+	for _, cc := range cases {
+		var calls int32
+		c, err := NewClient(cc.url, WithMiddleware(ResponseObserver(func(*http.Request, *http.Response, error) {
+			atomic.AddInt32(&calls, 1)
+		})))
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Retry = &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+
+		if _, err := c.HTTP("GET", "/v1/projects", nil); err == nil {
+			t.Errorf("%s expects error, but succeeded", cc.url)
+		}
+		if calls != cc.wantCalls {
+			t.Errorf("%s expects %d calls, but got %d", cc.url, cc.wantCalls, calls)
+		}
+	}
+}
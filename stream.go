@@ -0,0 +1,65 @@
+package lolp
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// streamLogLimit caps logged prefix of response body in stream mode
+const streamLogLimit = 4096
+
+// prefixLogBody passes through live response body, and logs its prefix
+// up to limit once reaching the limit, EOF or Close
+type prefixLogBody struct {
+	io.ReadCloser
+	limit int
+	buf   bytes.Buffer
+	once  sync.Once
+	log   func(prefix string, truncated bool)
+}
+
+// Read reads from body and keeps prefix
+func (b *prefixLogBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if rest := b.limit - b.buf.Len(); rest > 0 {
+		if n < rest {
+			rest = n
+		}
+		b.buf.Write(p[:rest])
+	}
+
+	if b.buf.Len() >= b.limit {
+		b.flush(true)
+	} else if err == io.EOF {
+		b.flush(false)
+	}
+
+	return n, err
+}
+
+// Close logs prefix read so far and closes body
+func (b *prefixLogBody) Close() error {
+	b.flush(b.buf.Len() >= b.limit)
+	return b.ReadCloser.Close()
+}
+
+// flush logs prefix once
+func (b *prefixLogBody) flush(truncated bool) {
+	b.once.Do(func() {
+		b.log(b.buf.String(), truncated)
+	})
+}
+
+// stream returns response keeping live body, which is logged up to limit
+func (c *Client) stream(res *http.Response, spath string) *http.Response {
+	res.Body = &prefixLogBody{
+		ReadCloser: res.Body,
+		limit:      streamLogLimit,
+		log: func(prefix string, truncated bool) {
+			c.logger().Debug("response body", "path", spath, "body", prefix, "truncated", truncated)
+		},
+	}
+	return res
+}
@@ -0,0 +1,72 @@
+package lolp
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	body := `"` + strings.Repeat("a", streamLogLimit*4) + `"`
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, body)
+	}))
+	defer s.Close()
+
+	l := &recordLogger{}
+	c, err := NewClient(s.URL, WithLogger(l))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := c.HTTP("GET", "/v1/logs", &RequestOptions{Stream: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := res.Body.(*prefixLogBody); !ok {
+		t.Fatalf("response body expects to be live, but got %T", res.Body)
+	}
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if string(b) != body {
+		t.Errorf("response body expects %d bytes, but got %d", len(body), len(b))
+	}
+
+	var logged []string
+	for _, line := range l.lines {
+		if strings.HasPrefix(line, "DEBUG response body") {
+			logged = append(logged, line)
+		}
+	}
+	if len(logged) != 1 {
+		t.Fatalf("response body expects to be logged once, but got %d", len(logged))
+	}
+	if !strings.Contains(logged[0], "truncated true") || len(logged[0]) > streamLogLimit+100 {
+		t.Errorf("logged body expects to be capped, but got %d bytes", len(logged[0]))
+	}
+}
+
+func TestStreamError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		io.WriteString(w, `{"errors":["Invalid"]}`)
+	}))
+	defer s.Close()
+
+	c, err := NewClient(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.HTTP("GET", "/v1/logs", &RequestOptions{Stream: true}); err == nil || err.Error() != "Invalid" {
+		t.Errorf("error response in stream mode expects to be parsed, but got %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package lolp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	// CABundleEnvVar for PEM file of additional CA certificates
+	CABundleEnvVar = "LOLP_CA_BUNDLE"
+
+	// ClientCertEnvVar for PEM file of client certificate
+	ClientCertEnvVar = "LOLP_CLIENT_CERT"
+
+	// ClientKeyEnvVar for PEM file of client private key
+	ClientKeyEnvVar = "LOLP_CLIENT_KEY"
+
+	// TLSMinVersionEnvVar for minimum TLS version such as "1.2"
+	TLSMinVersionEnvVar = "LOLP_TLS_MIN_VERSION"
+)
+
+// tlsVersions by name
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// ParseTLSVersion returns TLS version by name such as "1.2" or "TLS1.2"
+func ParseTLSVersion(s string) (uint16, error) {
+	v, ok := tlsVersions[strings.TrimPrefix(strings.ToUpper(s), "TLS")]
+	if !ok {
+		return 0, fmt.Errorf("client: unknown TLS version: %s", s)
+	}
+	return v, nil
+}
+
+// WithInsecureSkipVerify disables TLS certificate verification
+func WithInsecureSkipVerify() Option {
+	return func(c *Client) error {
+		t, err := c.transport()
+		if err != nil {
+			return err
+		}
+		t.TLSClientConfig.InsecureSkipVerify = true
+		return nil
+	}
+}
+
+// WithCABundle trusts CA certificates in PEM file in addition to system roots
+func WithCABundle(path string) Option {
+	return func(c *Client) error {
+		pem, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		t, err := c.transport()
+		if err != nil {
+			return err
+		}
+
+		pool := t.TLSClientConfig.RootCAs
+		if pool == nil {
+			if pool, err = x509.SystemCertPool(); err != nil {
+				pool = x509.NewCertPool()
+			}
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("client: no certificates in CA bundle: %s", path)
+		}
+		t.TLSClientConfig.RootCAs = pool
+
+		return nil
+	}
+}
+
+// WithClientCertificate presents certificate and key in PEM files for mutual TLS
+func WithClientCertificate(certFile, keyFile string) Option {
+	return func(c *Client) error {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return err
+		}
+
+		t, err := c.transport()
+		if err != nil {
+			return err
+		}
+		t.TLSClientConfig.Certificates = append(t.TLSClientConfig.Certificates, cert)
+
+		return nil
+	}
+}
+
+// WithMinTLSVersion sets minimum TLS version such as tls.VersionTLS12
+func WithMinTLSVersion(v uint16) Option {
+	return func(c *Client) error {
+		t, err := c.transport()
+		if err != nil {
+			return err
+		}
+		t.TLSClientConfig.MinVersion = v
+
+		return nil
+	}
+}
+
+// tlsEnvOptions returns options by TLS environment variables
+func tlsEnvOptions(getenv func(string) string) ([]Option, error) {
+	var opts []Option
+
+	if getenv(TLSNoVerifyEnvVar) != "" {
+		opts = append(opts, WithInsecureSkipVerify())
+	}
+
+	if ca := getenv(CABundleEnvVar); ca != "" {
+		opts = append(opts, WithCABundle(ca))
+	}
+
+	cert, key := getenv(ClientCertEnvVar), getenv(ClientKeyEnvVar)
+	switch {
+	case cert != "" && key != "":
+		opts = append(opts, WithClientCertificate(cert, key))
+	case cert != "" || key != "":
+		return nil, fmt.Errorf("client: both %s and %s are required", ClientCertEnvVar, ClientKeyEnvVar)
+	}
+
+	if s := getenv(TLSMinVersionEnvVar); s != "" {
+		v, err := ParseTLSVersion(s)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithMinTLSVersion(v))
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,128 @@
+package lolp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writePEM(t *testing.T, path, typ string, b []byte) {
+	if err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: b}), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func clientCertificate(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "lolp-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile := filepath.Join(dir, "client.pem"), filepath.Join(dir, "client-key.pem")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+
+	return certFile, keyFile
+}
+
+func TestTLSOptions(t *testing.T) {
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "lolp-client" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	s.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	s.StartTLS()
+	defer s.Close()
+
+	dir, err := ioutil.TempDir("", "lolp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	caFile := filepath.Join(dir, "ca.pem")
+	writePEM(t, caFile, "CERTIFICATE", s.Certificate().Raw)
+	certFile, keyFile := clientCertificate(t, dir)
+
+	c, err := NewClient(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.HTTP("GET", "/v1/projects", nil); err == nil {
+		t.Errorf("unknown CA expects error")
+	}
+
+	c, err = NewClient(s.URL, WithCABundle(caFile), WithClientCertificate(certFile, keyFile), WithMinTLSVersion(tls.VersionTLS12))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.HTTP("GET", "/v1/projects", nil); err != nil {
+		t.Errorf("expects to succeed with CA bundle and client certificate, but failed: %s", err)
+	}
+	if v := c.HTTPClient.Transport.(*http.Transport).TLSClientConfig.MinVersion; v != tls.VersionTLS12 {
+		t.Errorf("minimum TLS version expects %d, but got %d", tls.VersionTLS12, v)
+	}
+
+	if _, err := NewClient(s.URL, WithCABundle(keyFile)); err == nil {
+		t.Errorf("CA bundle without certificates expects error")
+	}
+}
+
+func TestTLSEnvOptions(t *testing.T) {
+	env := map[string]string{
+		ClientCertEnvVar:    "client.pem",
+		TLSMinVersionEnvVar: "1.3",
+	}
+	getenv := func(k string) string { return env[k] }
+
+	if _, err := tlsEnvOptions(getenv); err == nil {
+		t.Errorf("client certificate without key expects error")
+	}
+
+	delete(env, ClientCertEnvVar)
+	opts, err := tlsEnvOptions(getenv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewClient("https://api.example.com/", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := c.HTTPClient.Transport.(*http.Transport).TLSClientConfig.MinVersion; v != tls.VersionTLS13 {
+		t.Errorf("minimum TLS version expects %d, but got %d", tls.VersionTLS13, v)
+	}
+
+	if _, err := ParseTLSVersion("1.4"); err == nil {
+		t.Errorf("unknown TLS version expects error")
+	}
+}
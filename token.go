@@ -0,0 +1,100 @@
+package lolp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrOpaqueToken is returned by token which has no readable claims
+var ErrOpaqueToken = errors.New("client: token has no readable claims")
+
+// TokenInfo struct for claims of token
+type TokenInfo struct {
+	Subject   string    `json:"subject,omitempty"`
+	IssuedAt  time.Time `json:"issuedAt,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+}
+
+// MarshalJSON leaves out zero times, which omitempty does not
+func (ti TokenInfo) MarshalJSON() ([]byte, error) {
+	v := struct {
+		Subject   string     `json:"subject,omitempty"`
+		IssuedAt  *time.Time `json:"issuedAt,omitempty"`
+		ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	}{Subject: ti.Subject}
+	if !ti.IssuedAt.IsZero() {
+		v.IssuedAt = &ti.IssuedAt
+	}
+	if !ti.ExpiresAt.IsZero() {
+		v.ExpiresAt = &ti.ExpiresAt
+	}
+	return json.Marshal(v)
+}
+
+// ExpiresWithin returns whether token expires within d from now. Token
+// without expiry never expires.
+func (ti *TokenInfo) ExpiresWithin(d time.Duration) bool {
+	return !ti.ExpiresAt.IsZero() && time.Until(ti.ExpiresAt) <= d
+}
+
+// Expired returns whether token has expired
+func (ti *TokenInfo) Expired() bool {
+	return ti.ExpiresWithin(0)
+}
+
+// ParseToken returns claims in payload of JWT without verifying signature,
+// or ErrOpaqueToken for other tokens
+func ParseToken(token string) (*TokenInfo, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrOpaqueToken
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, ErrOpaqueToken
+	}
+
+	var claims struct {
+		Sub string  `json:"sub"`
+		Iat float64 `json:"iat"`
+		Exp float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(b, &claims); err != nil {
+		return nil, ErrOpaqueToken
+	}
+
+	ti := &TokenInfo{Subject: claims.Sub}
+	if claims.Iat > 0 {
+		ti.IssuedAt = time.Unix(int64(claims.Iat), 0)
+	}
+	if claims.Exp > 0 {
+		ti.ExpiresAt = time.Unix(int64(claims.Exp), 0)
+	}
+
+	return ti, nil
+}
+
+// TokenInfo returns claims of token of client
+func (c *Client) TokenInfo() (*TokenInfo, error) {
+	return c.TokenInfoContext(context.Background())
+}
+
+// TokenInfoContext returns claims of token of client, which is obtained by
+// credential provider of token when client has no token
+func (c *Client) TokenInfoContext(ctx context.Context) (*TokenInfo, error) {
+	token, err := c.existingToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, fmt.Errorf("client: missing token")
+	}
+
+	return ParseToken(token)
+}
@@ -0,0 +1,104 @@
+package lolp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// testJWT returns unsigned JWT with claims
+func testJWT(claims string) string {
+	enc := base64.RawURLEncoding
+	return fmt.Sprintf("%s.%s.%s", enc.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)), enc.EncodeToString([]byte(claims)), "signature")
+}
+
+func TestParseToken(t *testing.T) {
+	cases := []struct {
+		token     string
+		subject   string
+		issuedAt  int64
+		expiresAt int64
+		err       error
+	}{
+		{testJWT(`{"sub":"foo@example.com","iat":1500000000,"exp":1500003600}`), "foo@example.com", 1500000000, 1500003600, nil},
+		{testJWT(`{"sub":"foo@example.com"}`), "foo@example.com", 0, 0, nil},
+		{"0123456789abcdef", "", 0, 0, ErrOpaqueToken},
+		{"a.!!!.c", "", 0, 0, ErrOpaqueToken},
+		{testJWT(`not json`), "", 0, 0, ErrOpaqueToken},
+	}
+
+	for _, cc := range cases {
+		ti, err := ParseToken(cc.token)
+		if !errors.Is(err, cc.err) {
+			t.Errorf("token %s expects error %v, but got %v", cc.token, cc.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if ti.Subject != cc.subject {
+			t.Errorf("subject\nexpected: %s\nactual: %s", cc.subject, ti.Subject)
+		}
+		if cc.issuedAt > 0 && ti.IssuedAt.Unix() != cc.issuedAt || cc.issuedAt == 0 && !ti.IssuedAt.IsZero() {
+			t.Errorf("issued at\nexpected: %d\nactual: %s", cc.issuedAt, ti.IssuedAt)
+		}
+		if cc.expiresAt > 0 && ti.ExpiresAt.Unix() != cc.expiresAt || cc.expiresAt == 0 && !ti.ExpiresAt.IsZero() {
+			t.Errorf("expires at\nexpected: %d\nactual: %s", cc.expiresAt, ti.ExpiresAt)
+		}
+	}
+}
+
+func TestTokenInfo(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Unix()
+	c, err := NewClient("https://api.mc.lolipop.jp/", WithToken(testJWT(fmt.Sprintf(`{"sub":"foo","exp":%d}`, exp))))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ti, err := c.TokenInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ti.Expired() {
+		t.Errorf("token expects not to be expired")
+	}
+	if !ti.ExpiresWithin(2 * time.Hour) {
+		t.Errorf("token expects to expire within 2 hours")
+	}
+	if ti.ExpiresWithin(30 * time.Minute) {
+		t.Errorf("token expects not to expire within 30 minutes")
+	}
+
+	c.Token = ""
+	c.Credentials = StaticCredentials(testJWT(`{"sub":"bar"}`))
+	if ti, err = c.TokenInfo(); err != nil || ti.Subject != "bar" {
+		t.Errorf("token by provider expects subject bar, but got %#v, %v", ti, err)
+	}
+	if ti.Expired() {
+		t.Errorf("token without expiry expects not to be expired")
+	}
+}
+
+func TestTokenInfoJSON(t *testing.T) {
+	exp := time.Unix(1500003600, 0).UTC()
+	cases := []struct {
+		ti       TokenInfo
+		expected string
+	}{
+		{TokenInfo{Subject: "foo"}, `{"subject":"foo"}`},
+		{TokenInfo{Subject: "foo", ExpiresAt: exp}, `{"subject":"foo","expiresAt":"2017-07-14T03:40:00Z"}`},
+	}
+
+	for _, cc := range cases {
+		b, err := json.Marshal(cc.ti)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != cc.expected {
+			t.Errorf("expected: %s\nactual: %s", cc.expected, b)
+		}
+	}
+}